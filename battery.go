@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"image/color"
 
@@ -13,7 +14,6 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"github.com/schmidtw/muggo/assets"
 	"github.com/schmidtw/muggo/mug"
-	"github.com/schmidtw/muggo/mug/event"
 )
 
 type Battery struct {
@@ -75,31 +75,8 @@ func NewBattery(m *mug.Mug) *Battery {
 
 func (b *Battery) Start() {
 	go func() {
-		mugChanges := make(chan mug.BatteryInfo, 1)
-		b.m.AddMugListener(mug.MugListenerFunc(func(info mug.MugInfo) {
-			mugChanges <- info.Battery
-		}))
-
-		conChanges := make(chan event.ConnectionChange, 1)
-		b.m.AddConnectionChangeListener(event.ConnectionChangeFunc(func(info event.ConnectionChange) {
-			conChanges <- info
-		}))
-
-		for {
-			var info mug.BatteryInfo
-
-			select {
-			case info = <-mugChanges:
-			case con := <-conChanges:
-				if !con.Connected {
-					continue
-				}
-
-				all := b.m.All()
-				info = all.Battery
-			}
-
-			b.update(info)
+		for c := range b.m.WatchFields(context.Background(), mug.FieldBattery) {
+			b.update(c.Current.Battery)
 		}
 	}()
 }
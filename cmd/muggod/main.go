@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// muggod is a headless daemon that owns the bluetooth connection to a mug
+// and exposes it over HTTP with JSON.  See the rest package for the API, and
+// the rpc package for the optional gRPC API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/bridge"
+	"github.com/schmidtw/muggo/mug/metrics"
+	"github.com/schmidtw/muggo/mug/rest"
+	"github.com/schmidtw/muggo/mug/rpc"
+	"github.com/schmidtw/muggo/mug/rpc/mugv1"
+	"google.golang.org/grpc"
+)
+
+type config struct {
+	listen   string
+	address  string
+	metrics  bool
+	mqtt     string
+	mqttNode string
+	grpc     string
+	logLevel slog.Level
+}
+
+func main() {
+	var cfg config
+
+	flag.StringVar(&cfg.listen, "listen", ":8080", "the address to serve the API on")
+	flag.StringVar(&cfg.address, "address", "", "the address of the mug, the first mug found is used if empty")
+	flag.BoolVar(&cfg.metrics, "metrics", false, "serve Prometheus metrics at /metrics")
+	flag.StringVar(&cfg.mqtt, "mqtt", "", "bridge the mug to this MQTT broker, e.g. tcp://localhost:1883")
+	flag.StringVar(&cfg.mqttNode, "mqtt-node", "", "the MQTT node id, required with -mqtt if -address is not set")
+	flag.StringVar(&cfg.grpc, "grpc", "", "also serve the gRPC API on this address, e.g. :9090")
+	flag.TextVar(&cfg.logLevel, "log-level", slog.LevelInfo, "the log level")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.logLevel}))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg, logger); err != nil {
+		logger.Error("muggod failed", slog.Any("err", err))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg config, logger *slog.Logger) error {
+	opts := []mug.Option{
+		mug.WithLogger(logger),
+	}
+	if cfg.address != "" {
+		opts = append(opts, mug.WithAddress(cfg.address))
+	}
+
+	m, err := mug.New(opts...)
+	if err != nil {
+		return err
+	}
+
+	m.Start()
+	defer m.Stop()
+
+	handler := rest.NewHandler(m, logger)
+	defer handler.Close()
+
+	if cfg.metrics {
+		exporter := metrics.New()
+		defer exporter.Watch(m)()
+		handler.Handle("GET /metrics", exporter.Handler())
+	}
+
+	// Stop the bridge when run returns early, not only on a signal.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if cfg.mqtt != "" {
+		done, err := startBridge(ctx, m, cfg.mqtt, cfg.mqttNode, logger)
+		if err != nil {
+			return err
+		}
+		defer func() { <-done }()
+		defer cancel()
+	}
+
+	if cfg.grpc != "" {
+		stop, err := startGRPC(m, cfg.grpc, logger)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+
+	srv := http.Server{
+		Addr:              cfg.listen,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Shutdown waits for the requests to finish, and the event streams only
+	// finish when the handler is closed.
+	srv.RegisterOnShutdown(handler.Close)
+
+	errs := make(chan error, 1)
+	go func() {
+		logger.Info("serving the mug", slog.String("listen", cfg.listen))
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdown); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// startBridge connects to the MQTT broker and runs the bridge until the
+// context is done.  The returned channel is closed once the bridge stops.
+func startBridge(ctx context.Context, m *mug.Mug, broker, node string, logger *slog.Logger) (<-chan struct{}, error) {
+	bopts := []bridge.Option{
+		bridge.WithLogger(logger),
+	}
+	if node != "" {
+		bopts = append(bopts, bridge.WithNodeID(node))
+	}
+
+	// The will tells Home Assistant the mug is unavailable if the daemon
+	// goes away.
+	availability, err := bridge.AvailabilityTopic(m, bopts...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(broker).
+		SetClientID("muggod-"+m.Address().String()).
+		SetAutoReconnect(true).
+		SetWill(availability, "offline", 1, true)
+
+	client := mqtt.NewClient(opts)
+	b, err := bridge.New(m, client, bopts...)
+	if err != nil {
+		return nil, err
+	}
+
+	token := client.Connect()
+	if !token.WaitTimeout(10 * time.Second) {
+		return nil, fmt.Errorf("connecting to %s: %w", broker, bridge.ErrTimeout)
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", broker, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer client.Disconnect(250)
+
+		if err := b.Run(ctx); err != nil {
+			logger.Error("the mqtt bridge stopped", slog.Any("err", err))
+		}
+	}()
+
+	return done, nil
+}
+
+// startGRPC serves the gRPC API on the address.  The returned function stops
+// the server.
+func startGRPC(m *mug.Mug, addr string, logger *slog.Logger) (func(), error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := grpc.NewServer()
+	mugv1.RegisterMugServiceServer(srv, rpc.NewServer(m))
+
+	go func() {
+		logger.Info("serving the gRPC API", slog.String("listen", addr))
+		if err := srv.Serve(lis); err != nil {
+			logger.Error("the gRPC server stopped", slog.Any("err", err))
+		}
+	}()
+
+	// Stop rather than GracefulStop, since a Watch never ends on its own.
+	return srv.Stop, nil
+}
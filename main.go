@@ -4,21 +4,28 @@
 package main
 
 import (
-	"fmt"
+	"flag"
+	"log/slog"
+	"net/http"
 
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"github.com/schmidtw/muggo/mug"
 	"github.com/schmidtw/muggo/mug/event"
+	"github.com/schmidtw/muggo/mug/metrics"
 )
 
 func main() {
+	metricsAddr := flag.String("metrics", "", "serve Prometheus metrics on this address, e.g. :9090")
+	flag.Parse()
 
 	m, err := mug.New(
 		mug.WithChangeConnectionListener(
 			event.ConnectionChangeFunc(
 				func(evnt event.ConnectionChange) {
-					fmt.Printf("%s, Connected: %v\n", evnt.Address.String(), evnt.Connected)
+					slog.Info("connection changed",
+						slog.String("address", evnt.Address.String()),
+						slog.Bool("connected", evnt.Connected))
 				},
 			)),
 	)
@@ -26,6 +33,10 @@ func main() {
 		panic(err)
 	}
 
+	if *metricsAddr != "" {
+		serveMetrics(m, *metricsAddr)
+	}
+
 	m.Start()
 
 	a := app.New()
@@ -49,3 +60,17 @@ func main() {
 	w.SetContent(info)
 	w.ShowAndRun()
 }
+
+// serveMetrics serves the mug's metrics at /metrics on the address.
+func serveMetrics(m *mug.Mug, addr string) {
+	exporter := metrics.New()
+	exporter.Watch(m)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter.Handler())
+
+	go func() {
+		err := http.ListenAndServe(addr, mux)
+		slog.Error("the metrics server stopped", slog.Any("err", err))
+	}()
+}
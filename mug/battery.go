@@ -4,6 +4,8 @@
 package mug
 
 import (
+	"context"
+	"slices"
 	"time"
 
 	"github.com/schmidtw/muggo/units"
@@ -16,7 +18,12 @@ type BatteryInfo struct {
 }
 
 func (m *Mug) BatteryInfo() (BatteryInfo, error) {
-	data, changed, err := m.io(m, mugApi_BATTERY, 5)
+	return m.BatteryInfoContext(context.Background())
+}
+
+// BatteryInfoContext is like BatteryInfo but uses the context for the request.
+func (m *Mug) BatteryInfoContext(ctx context.Context) (BatteryInfo, error) {
+	data, changed, err := m.io(ctx, m, mugApi_BATTERY, 5)
 	if err != nil {
 		return BatteryInfo{}, err
 	}
@@ -28,6 +35,10 @@ func (m *Mug) BatteryInfo() (BatteryInfo, error) {
 }
 
 func batteryInfoFromData(data []byte) BatteryInfo {
+	if len(data) < 4 {
+		return BatteryInfo{}
+	}
+
 	return BatteryInfo{
 		PercentLeft: float64(data[0]), // 0-100
 		Charging:    !(data[1] == 0),  // 0 = not charging, 1 = charging
@@ -36,35 +47,35 @@ func batteryInfoFromData(data []byte) BatteryInfo {
 }
 
 func (m *Mug) charging() {
-	m.m.Lock()
-
-	api := m.apis[mugApi_BATTERY]
-	if api.data[1] == 1 {
-		m.m.Unlock()
-		return
-	}
-
-	api.data[1] = 1
-	api.fetched = m.now()
-	m.m.Unlock()
-
-	m.dispatch()
+	m.setCharging(1)
 }
 
 func (m *Mug) discharging() {
+	m.setCharging(0)
+}
+
+// setCharging updates the charging flag in the cached battery data.
+func (m *Mug) setCharging(flag byte) {
 	m.m.Lock()
 
 	api := m.apis[mugApi_BATTERY]
-	if api.data[1] == 0 {
+	if len(api.data) < 2 || api.data[1] == flag {
 		m.m.Unlock()
 		return
 	}
 
-	api.data[1] = 0
+	// The cached data is shared with readers outside the lock, so it is
+	// replaced rather than changed in place.
+	data := slices.Clone(api.data)
+	data[1] = flag
+	api.data = data
 	api.fetched = m.now()
+	motions := m.motion.coaster(flag == 1)
+	last := m.motion.last
 	m.m.Unlock()
 
 	m.dispatch()
+	m.notifyMotion(last, motions...)
 }
 
 func (m *Mug) refreshbattery() {
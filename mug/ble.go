@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/xmidt-org/eventor"
+	bt "tinygo.org/x/bluetooth"
+)
+
+// bleTransport is the default transport that uses a bluetooth adapter to scan
+// for and connect to a mug.  A mug that is already connected is skipped, so
+// a Fleet can use the same transport to connect to any number of mugs.
+type bleTransport struct {
+	m            sync.Mutex
+	enabled      bool
+	logger       *slog.Logger
+	adapter      *bt.Adapter
+	address      bt.Address
+	serviceUUIDs []bt.UUID
+
+	// disconnects are closed when the connected mugs disconnect.
+	disconnects map[bt.Address]chan struct{}
+}
+
+var _ Transport = (*bleTransport)(nil)
+
+func newBLETransport(logger *slog.Logger, adapter *bt.Adapter, address bt.Address, uuids []bt.UUID) *bleTransport {
+	return &bleTransport{
+		logger:       logger,
+		adapter:      adapter,
+		address:      address,
+		serviceUUIDs: uuids,
+		disconnects:  make(map[bt.Address]chan struct{}),
+	}
+}
+
+func (b *bleTransport) Connect(ctx context.Context) (*Connection, error) {
+	if err := b.enable(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := b.scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	disconnected := make(chan struct{})
+	b.m.Lock()
+	b.disconnects[result.Address] = disconnected
+	b.m.Unlock()
+
+	conn, err := bleConnect(ctx, b.logger, b.adapter, result.Address, b.serviceUUIDs)
+	if err != nil {
+		b.m.Lock()
+		delete(b.disconnects, result.Address)
+		b.m.Unlock()
+		return nil, err
+	}
+	conn.Disconnected = disconnected
+
+	return conn, nil
+}
+
+// enable enables the adapter the first time it is used.
+func (b *bleTransport) enable(ctx context.Context) error {
+	b.m.Lock()
+	enabled := b.enabled
+	b.m.Unlock()
+
+	if enabled {
+		return nil
+	}
+
+	if err := enableAdapter(ctx, b.logger, b.adapter); err != nil {
+		return err
+	}
+	addConnectHandler(b.adapter, b.connectHandler)
+
+	b.m.Lock()
+	b.enabled = true
+	b.m.Unlock()
+
+	return nil
+}
+
+func (b *bleTransport) connectHandler(device bt.Device, connected bool) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if connected {
+		return
+	}
+
+	if ch, ok := b.disconnects[device.Address]; ok {
+		close(ch)
+		delete(b.disconnects, device.Address)
+	}
+}
+
+// isConnected returns if the transport is connected to the mug.
+func (b *bleTransport) isConnected(address bt.Address) bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	_, ok := b.disconnects[address]
+	return ok
+}
+
+// connectHandlers share each adapter's connect handler, since an adapter
+// only has one, between all of the mugs and fleets using the adapter.
+var connectHandlers = struct {
+	m        sync.Mutex
+	adapters map[*bt.Adapter]*eventor.Eventor[func(bt.Device, bool)]
+}{
+	adapters: make(map[*bt.Adapter]*eventor.Eventor[func(bt.Device, bool)]),
+}
+
+// addConnectHandler adds a handler that is called when a device connects to
+// or disconnects from the adapter.
+func addConnectHandler(adapter *bt.Adapter, handler func(bt.Device, bool)) CancelFunc {
+	connectHandlers.m.Lock()
+	defer connectHandlers.m.Unlock()
+
+	handlers, ok := connectHandlers.adapters[adapter]
+	if !ok {
+		handlers = &eventor.Eventor[func(bt.Device, bool)]{}
+		connectHandlers.adapters[adapter] = handlers
+		adapter.SetConnectHandler(func(device bt.Device, connected bool) {
+			handlers.Visit(func(h func(bt.Device, bool)) {
+				h(device, connected)
+			})
+		})
+	}
+
+	return CancelFunc(handlers.Add(handler))
+}
+
+func (b *bleTransport) scan(ctx context.Context) (*bt.ScanResult, error) {
+	type scanResult struct {
+		result bt.ScanResult
+		err    error
+	}
+
+	ch := make(chan scanResult, 1)
+
+	go func() {
+		err := b.adapter.Scan(func(adapter *bt.Adapter, r bt.ScanResult) {
+			if b.isConnected(r.Address) {
+				return
+			}
+			if r.Address == b.address || hasServiceUUID(r, b.serviceUUIDs) {
+				_ = adapter.StopScan()
+				ch <- scanResult{result: r}
+			}
+		})
+		if err != nil {
+			ch <- scanResult{err: err}
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		_ = b.adapter.StopScan()
+		return nil, ctx.Err()
+	case result := <-ch:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return &result.result, nil
+	}
+}
+
+func hasServiceUUID(r bt.ScanResult, uuids []bt.UUID) bool {
+	for _, service := range uuids {
+		if r.HasServiceUUID(service) {
+			return true
+		}
+	}
+	return false
+}
+
+// bleConnect connects to the mug at the specified address and discovers the
+// characteristics of the wanted services.
+func bleConnect(ctx context.Context, logger *slog.Logger, adapter *bt.Adapter, address bt.Address, uuids []bt.UUID) (*Connection, error) {
+	logger.Info("connecting to mug", addressAttr(address))
+	reportState(ctx, event.Connecting, nil)
+	device, err := adapter.Connect(address, bt.ConnectionParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	reportState(ctx, event.DiscoveringServices, nil)
+
+	services, err := device.DiscoverServices(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := Connection{
+		Address:    address,
+		Disconnect: device.Disconnect,
+	}
+	for _, service := range services {
+		if !isWantedService(uuids, service.UUID()) {
+			continue
+		}
+
+		chars, err := service.DiscoverCharacteristics(nil)
+		if err != nil {
+			return nil, err
+		}
+		conn.ServiceUUIDs = append(conn.ServiceUUIDs, service.UUID())
+		logger.Debug("discovered service", addressAttr(address),
+			slog.String("uuid", service.UUID().String()),
+			slog.Int("characteristics", len(chars)))
+
+		for i := range chars {
+			conn.Characteristics = append(conn.Characteristics, &chars[i])
+		}
+	}
+
+	return &conn, nil
+}
+
+func isWantedService(uuids []bt.UUID, uuid bt.UUID) bool {
+	for _, service := range uuids {
+		if uuid.String() == service.String() {
+			return true
+		}
+	}
+	return false
+}
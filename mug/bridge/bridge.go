@@ -0,0 +1,386 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bridge publishes the state of a mug to MQTT, along with Home
+// Assistant discovery configs, and applies the commands sent to it.
+//
+// The topics used, relative to the prefix and node id, are:
+//
+//	availability     "online" or "offline"
+//	state            the mug information as JSON
+//	led              the LED as Home Assistant JSON light state
+//	target/set       a target temperature such as "57" (°C) or "135F"
+//	units/set        "C" or "F"
+//	name/set         the name of the mug
+//	led/set          a Home Assistant JSON light command
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/schmidtw/muggo/units"
+)
+
+var (
+	ErrTimeout = errors.New("timed out waiting for the broker")
+)
+
+const (
+	online  = "online"
+	offline = "offline"
+
+	// maxPendingCommands is how many commands can wait for the mug before
+	// new ones are dropped.
+	maxPendingCommands = 16
+)
+
+// Bridge connects a mug to an MQTT broker.
+type Bridge struct {
+	m         *mug.Mug
+	client    mqtt.Client
+	prefix    string
+	discovery string
+	nodeID    string
+	timeout   time.Duration
+	logger    *slog.Logger
+}
+
+// New creates a bridge between the mug and the MQTT client.  The client
+// should already be connected, and should use AvailabilityTopic() with the
+// "offline" payload as its will so Home Assistant knows when the bridge goes
+// away.
+func New(m *mug.Mug, client mqtt.Client, opts ...Option) (*Bridge, error) {
+	if client == nil {
+		return nil, ErrInvalidInput
+	}
+
+	b, err := newBridge(m, opts...)
+	if err != nil {
+		return nil, err
+	}
+	b.client = client
+
+	return b, nil
+}
+
+// AvailabilityTopic returns the availability topic a bridge for the mug with
+// the options uses, so it can be set as the will of the client before the
+// bridge is created.
+func AvailabilityTopic(m *mug.Mug, opts ...Option) (string, error) {
+	b, err := newBridge(m, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return b.AvailabilityTopic(), nil
+}
+
+// newBridge creates a bridge without a client.
+func newBridge(m *mug.Mug, opts ...Option) (*Bridge, error) {
+	if m == nil {
+		return nil, ErrInvalidInput
+	}
+
+	b := Bridge{
+		m:         m,
+		prefix:    "muggo",
+		discovery: "homeassistant",
+		nodeID:    nodeID(m),
+		timeout:   10 * time.Second,
+		logger:    slog.Default(),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&b); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Without an address every mug would share the same topics.
+	if b.nodeID == "" {
+		return nil, fmt.Errorf("%w: the mug address is not known, use WithNodeID()", ErrInvalidInput)
+	}
+
+	return &b, nil
+}
+
+// nodeID returns the node id for the mug, or "" if the address is not known.
+func nodeID(m *mug.Mug) string {
+	addr := m.Address().String()
+	if addr == "" || addr == "00:00:00:00:00:00" {
+		return ""
+	}
+
+	return strings.ToLower(strings.ReplaceAll(addr, ":", ""))
+}
+
+// AvailabilityTopic returns the topic that says if the mug is available.
+func (b *Bridge) AvailabilityTopic() string {
+	return b.topic("availability")
+}
+
+func (b *Bridge) topic(name string) string {
+	return b.prefix + "/" + b.nodeID + "/" + name
+}
+
+// command is a message received on a command topic, waiting to be applied.
+type command struct {
+	topic   string
+	payload []byte
+	apply   func(context.Context, []byte) error
+}
+
+// Run publishes the state of the mug and applies the commands sent to it
+// until the context is done.
+func (b *Bridge) Run(ctx context.Context) error {
+	// The commands are applied by a single worker, since writing to the mug
+	// blocks and the client must not be held up in its callbacks.
+	pending := make(chan command, maxPendingCommands)
+	wctx, cancel := context.WithCancel(ctx)
+	worker := make(chan struct{})
+	go func() {
+		defer close(worker)
+		b.apply(wctx, pending)
+	}()
+	defer func() {
+		cancel()
+		<-worker
+	}()
+
+	commands := map[string]func(context.Context, []byte) error{
+		"target/set": b.setTarget,
+		"units/set":  b.setUnits,
+		"name/set":   b.setName,
+		"led/set":    b.setLED,
+	}
+	for name, fn := range commands {
+		topic := b.topic(name)
+		token := b.client.Subscribe(topic, 1, func(_ mqtt.Client, msg mqtt.Message) {
+			select {
+			case pending <- command{topic: msg.Topic(), payload: msg.Payload(), apply: fn}:
+			default:
+				b.logger.Warn("mqtt command dropped, too many are pending",
+					slog.String("topic", msg.Topic()),
+					slog.String("payload", string(msg.Payload())))
+			}
+		})
+		if err := b.wait(token); err != nil {
+			return fmt.Errorf("subscribing to %s: %w", topic, err)
+		}
+	}
+	defer func() {
+		topics := make([]string, 0, len(commands))
+		for name := range commands {
+			topics = append(topics, b.topic(name))
+		}
+		_ = b.wait(b.client.Unsubscribe(topics...))
+	}()
+
+	// Only the latest state matters, so a single slot is plenty.
+	cancelInfo := b.m.AddMugListener(mug.MugListenerFunc(b.publishState), mug.QueueSize(1))
+	defer cancelInfo()
+
+	cancelConn := b.m.AddConnectionChangeListener(event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+		b.availability(cc.Connected)
+	}))
+	defer cancelConn()
+
+	b.availability(b.m.IsConnected())
+
+	<-ctx.Done()
+
+	return b.wait(b.client.Publish(b.AvailabilityTopic(), 1, true, offline))
+}
+
+// apply applies the pending commands in order until the context is done.
+func (b *Bridge) apply(ctx context.Context, pending <-chan command) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case cmd := <-pending:
+			if err := cmd.apply(ctx, cmd.payload); err != nil {
+				b.logger.Warn("mqtt command failed",
+					slog.String("topic", cmd.topic),
+					slog.String("payload", string(cmd.payload)),
+					slog.Any("err", err))
+				continue
+			}
+			b.publishState(b.m.All())
+		}
+	}
+}
+
+// availability announces the mug and its state when it connects, and says
+// it is unavailable when it disconnects.
+func (b *Bridge) availability(connected bool) {
+	if !connected {
+		b.publish(b.AvailabilityTopic(), offline)
+		return
+	}
+
+	info := b.m.All()
+	for topic, config := range b.discoveryConfigs(info) {
+		b.publish(topic, config)
+	}
+	b.publishState(info)
+	b.publish(b.AvailabilityTopic(), online)
+}
+
+// state is the mug information published to the state topic.
+type state struct {
+	Name               string  `json:"name"`
+	Drink              float64 `json:"drink"`
+	Target             float64 `json:"target"`
+	Battery            float64 `json:"battery"`
+	BatteryTemperature float64 `json:"battery_temperature"`
+	Charging           bool    `json:"charging"`
+	LiquidLevel        float64 `json:"liquid_level"`
+	State              string  `json:"state"`
+	Action             string  `json:"action"`
+	Units              string  `json:"units"`
+}
+
+// ledState is the Home Assistant JSON light state and command.
+type ledState struct {
+	State      string    `json:"state"`
+	ColorMode  string    `json:"color_mode,omitempty"`
+	Brightness *uint8    `json:"brightness,omitempty"`
+	Color      *ledColor `json:"color,omitempty"`
+}
+
+type ledColor struct {
+	R uint8 `json:"r"`
+	G uint8 `json:"g"`
+	B uint8 `json:"b"`
+}
+
+// action maps the mug state to the Home Assistant climate action.
+func action(s mug.State) string {
+	switch s {
+	case mug.Heating:
+		return "heating"
+	case mug.Empty, mug.Unknown:
+		return "off"
+	}
+	return "idle"
+}
+
+func (b *Bridge) publishState(info mug.MugInfo) {
+	b.publish(b.topic("state"), state{
+		Name:               info.Name,
+		Drink:              info.Drink.C(),
+		Target:             info.Target.C(),
+		Battery:            info.Battery.PercentLeft,
+		BatteryTemperature: info.Battery.Temp.C(),
+		Charging:           info.Battery.Charging,
+		LiquidLevel:        info.LiquidLevel.Percent,
+		State:              info.State.String(),
+		Action:             action(info.State),
+		Units:              string(info.Units),
+	})
+
+	if info.Model.Capabilities().LED {
+		ls := ledState{
+			State:      "OFF",
+			ColorMode:  "rgb",
+			Brightness: &info.LED.A,
+			Color:      &ledColor{R: info.LED.R, G: info.LED.G, B: info.LED.B},
+		}
+		if info.LED.A > 0 {
+			ls.State = "ON"
+		}
+		b.publish(b.topic("led"), ls)
+	}
+}
+
+// publish sends the retained message without waiting for the broker, so a
+// slow broker never holds up the mug.  Payloads that are not strings are
+// sent as JSON.
+func (b *Bridge) publish(topic string, payload any) {
+	if s, ok := payload.(string); !ok {
+		buf, err := json.Marshal(payload)
+		if err != nil {
+			b.logger.Error("unable to encode the mqtt payload", slog.String("topic", topic), slog.Any("err", err))
+			return
+		}
+		payload = buf
+	} else {
+		payload = []byte(s)
+	}
+
+	token := b.client.Publish(topic, 1, true, payload)
+	go func() {
+		if err := b.wait(token); err != nil {
+			b.logger.Warn("mqtt publish failed", slog.String("topic", topic), slog.Any("err", err))
+		}
+	}()
+}
+
+func (b *Bridge) wait(token mqtt.Token) error {
+	if !token.WaitTimeout(b.timeout) {
+		return ErrTimeout
+	}
+	return token.Error()
+}
+
+func (b *Bridge) setTarget(ctx context.Context, payload []byte) error {
+	temp, err := units.ParseTemperature(string(payload))
+	if err != nil {
+		return err
+	}
+
+	_, err = b.m.TargetContext(ctx, temp)
+	return err
+}
+
+func (b *Bridge) setUnits(ctx context.Context, payload []byte) error {
+	unit := units.TemperatureUnit(strings.ToUpper(strings.TrimSpace(string(payload))))
+
+	_, err := b.m.UnitsContext(ctx, unit)
+	return err
+}
+
+func (b *Bridge) setName(ctx context.Context, payload []byte) error {
+	_, err := b.m.NameContext(ctx, string(payload))
+	return err
+}
+
+func (b *Bridge) setLED(ctx context.Context, payload []byte) error {
+	var cmd ledState
+	if err := json.Unmarshal(payload, &cmd); err != nil {
+		return errors.Join(ErrInvalidInput, err)
+	}
+
+	led, err := b.m.LedContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	rgba := *led
+	if cmd.Color != nil {
+		rgba.R, rgba.G, rgba.B = cmd.Color.R, cmd.Color.G, cmd.Color.B
+	}
+	switch {
+	case strings.EqualFold(cmd.State, "OFF"):
+		rgba.A = 0
+	case cmd.Brightness != nil:
+		rgba.A = *cmd.Brightness
+	case rgba.A == 0:
+		rgba.A = 0xff
+	}
+
+	_, err = b.m.LedContext(ctx, rgba)
+	return err
+}
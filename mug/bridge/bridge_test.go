@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	server "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/sim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startBroker starts an embedded MQTT broker and returns its address.
+func startBroker(t *testing.T) string {
+	t.Helper()
+
+	// Find a free port for the broker.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	broker := server.New(&server.Options{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	require.NoError(t, broker.AddHook(new(auth.AllowHook), nil))
+	require.NoError(t, broker.AddListener(listeners.NewTCP(listeners.Config{
+		ID:      "tcp",
+		Address: addr,
+	})))
+	require.NoError(t, broker.Serve())
+	t.Cleanup(func() { _ = broker.Close() })
+
+	return addr
+}
+
+func connect(t *testing.T, addr, id string) mqtt.Client {
+	t.Helper()
+
+	opts := mqtt.NewClientOptions().
+		AddBroker("tcp://" + addr).
+		SetClientID(id).
+		SetConnectTimeout(time.Second)
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	require.True(t, token.WaitTimeout(time.Second))
+	require.NoError(t, token.Error())
+	t.Cleanup(func() { client.Disconnect(0) })
+
+	return client
+}
+
+// retained collects the latest message on every topic.
+type retained struct {
+	m     sync.Mutex
+	msgs  map[string][]byte
+	order []string
+}
+
+func watch(t *testing.T, client mqtt.Client) *retained {
+	t.Helper()
+
+	r := retained{msgs: make(map[string][]byte)}
+	token := client.Subscribe("#", 1, func(_ mqtt.Client, msg mqtt.Message) {
+		r.m.Lock()
+		defer r.m.Unlock()
+
+		r.msgs[msg.Topic()] = msg.Payload()
+	})
+	require.True(t, token.WaitTimeout(time.Second))
+	require.NoError(t, token.Error())
+
+	return &r
+}
+
+func (r *retained) get(topic string) ([]byte, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	msg, ok := r.msgs[topic]
+	return msg, ok
+}
+
+// json returns the value of the key in the JSON message on the topic.
+func (r *retained) json(topic, key string) any {
+	msg, ok := r.get(topic)
+	if !ok {
+		return nil
+	}
+
+	var v map[string]any
+	if err := json.Unmarshal(msg, &v); err != nil {
+		return nil
+	}
+	return v[key]
+}
+
+func TestBridge(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	addr := startBroker(t)
+	observer := watch(t, connect(t, addr, "observer"))
+
+	s, err := sim.New(sim.WithDrink(60), sim.WithTarget(57))
+	require.NoError(err)
+
+	m, err := mug.New(
+		mug.WithTransport(s),
+		mug.WithAddress("00:00:5E:00:53:01"),
+		mug.WithLogger(nil),
+	)
+	require.NoError(err)
+
+	b, err := New(m, connect(t, addr, "bridge"), WithLogger(nil))
+	require.NoError(err)
+	assert.Equal("muggo/00005e005301/availability", b.AvailabilityTopic())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Run(ctx)
+	}()
+
+	const (
+		base    = "muggo/00005e005301/"
+		climate = "homeassistant/climate/00005e005301/drink/config"
+	)
+
+	// Nothing is announced until the mug connects.
+	assert.Eventually(func() bool {
+		msg, _ := observer.get(base + "availability")
+		return string(msg) == offline
+	}, time.Second, 10*time.Millisecond)
+	_, ok := observer.get(climate)
+	assert.False(ok)
+
+	m.Start()
+	defer m.Stop()
+
+	assert.Eventually(func() bool {
+		msg, _ := observer.get(base + "availability")
+		return string(msg) == online &&
+			observer.json(base+"state", "drink") == 60.0
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(base+"target/set", observer.json(climate, "temperature_command_topic"))
+	assert.Equal("Battery", observer.json("homeassistant/sensor/00005e005301/battery/config", "name"))
+	assert.Equal("Liquid level", observer.json("homeassistant/sensor/00005e005301/liquid_level/config", "name"))
+	assert.Equal(base+"led/set", observer.json("homeassistant/light/00005e005301/led/config", "command_topic"))
+	assert.Equal(57.0, observer.json(base+"state", "target"))
+	assert.Equal("ON", observer.json(base+"led", "state"))
+
+	// The commands are applied to the mug.
+	commander := connect(t, addr, "commander")
+	commands := []struct {
+		topic   string
+		payload string
+		key     string
+		want    any
+	}{
+		{topic: "target/set", payload: "55", key: "target", want: 55.0},
+		{topic: "units/set", payload: "f", key: "units", want: "F"},
+		{topic: "name/set", payload: "Coffee", key: "name", want: "Coffee"},
+		{topic: "led/set", payload: `{"state":"OFF"}`, key: "state", want: "OFF"},
+	}
+	for _, cmd := range commands {
+		commander.Publish(base+cmd.topic, 1, false, cmd.payload).Wait()
+
+		state := base + "state"
+		if cmd.topic == "led/set" {
+			state = base + "led"
+		}
+		assert.Eventually(func() bool {
+			return observer.json(state, cmd.key) == cmd.want
+		}, time.Second, 10*time.Millisecond, cmd.topic)
+	}
+
+	// A bad command is ignored.
+	commander.Publish(base+"target/set", 1, false, "hot").Wait()
+
+	m.Stop()
+	assert.Eventually(func() bool {
+		msg, _ := observer.get(base + "availability")
+		return string(msg) == offline
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.NoError(<-done)
+}
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+
+	m, err := mug.New(mug.WithLogger(nil))
+	assert.NoError(err)
+
+	b, err := New(m, mqtt.NewClient(mqtt.NewClientOptions()),
+		WithTopicPrefix("/home/mugs/"),
+		WithDiscoveryPrefix("ha"),
+		WithNodeID("desk"),
+	)
+	assert.NoError(err)
+	assert.Equal("home/mugs/desk/availability", b.AvailabilityTopic())
+	assert.Equal("ha/sensor/desk/battery/config", b.discoveryTopic("sensor", "battery"))
+
+	// Without an address the node id is required.
+	_, err = New(m, mqtt.NewClient(mqtt.NewClientOptions()))
+	assert.ErrorIs(err, ErrInvalidInput)
+	_, err = AvailabilityTopic(m)
+	assert.ErrorIs(err, ErrInvalidInput)
+
+	// The address is used when it is known.
+	m, err = mug.New(mug.WithLogger(nil), mug.WithAddress("C8:2B:96:01:02:03"))
+	assert.NoError(err)
+	b, err = New(m, mqtt.NewClient(mqtt.NewClientOptions()))
+	assert.NoError(err)
+	assert.Equal("muggo/c82b96010203/availability", b.AvailabilityTopic())
+
+	// The topic is known before there is a client.
+	topic, err := AvailabilityTopic(m, WithTopicPrefix("home"), WithNodeID("desk"))
+	assert.NoError(err)
+	assert.Equal("home/desk/availability", topic)
+	_, err = AvailabilityTopic(nil)
+	assert.ErrorIs(err, ErrInvalidInput)
+
+	_, err = New(m, nil)
+	assert.ErrorIs(err, ErrInvalidInput)
+	_, err = New(m, mqtt.NewClient(mqtt.NewClientOptions()), WithNodeID("a/b"))
+	assert.ErrorIs(err, ErrInvalidInput)
+	_, err = New(m, mqtt.NewClient(mqtt.NewClientOptions()), WithTimeout(0))
+	assert.ErrorIs(err, ErrInvalidInput)
+}
+
+// failedToken is a token that is already done with an error.
+type failedToken struct {
+	mqtt.Token
+	err error
+}
+
+func (t failedToken) WaitTimeout(time.Duration) bool { return true }
+func (t failedToken) Error() error                   { return t.err }
+
+// failingClient is a client whose subscriptions always fail.
+type failingClient struct {
+	mqtt.Client
+	err error
+}
+
+func (c failingClient) Subscribe(string, byte, mqtt.MessageHandler) mqtt.Token {
+	return failedToken{err: c.err}
+}
+
+func TestBridge_Run_subscribeFails(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	m, err := mug.New(mug.WithLogger(nil), mug.WithAddress("00:00:5E:00:53:01"))
+	require.NoError(err)
+
+	errUnknown := errors.New("unknown")
+	b, err := New(m, failingClient{err: errUnknown}, WithLogger(nil))
+	require.NoError(err)
+
+	// The context is never cancelled, so Run must return on its own.
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Run(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(err, errUnknown)
+	case <-time.After(time.Second):
+		assert.Fail("Run did not return when subscribing failed")
+	}
+}
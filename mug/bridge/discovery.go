@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"strconv"
+
+	"github.com/schmidtw/muggo/mug"
+)
+
+// device describes the mug to Home Assistant.
+type device struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	Model        string   `json:"model"`
+	SWVersion    string   `json:"sw_version,omitempty"`
+	HWVersion    string   `json:"hw_version,omitempty"`
+}
+
+// discoveryConfigs returns the Home Assistant discovery configs for the mug,
+// keyed by the topic they are published to.
+func (b *Bridge) discoveryConfigs(info mug.MugInfo) map[string]map[string]any {
+	name := info.Name
+	if name == "" {
+		name = "Ember Mug"
+	}
+
+	dev := device{
+		Identifiers:  []string{"muggo_" + b.nodeID},
+		Name:         name,
+		Manufacturer: "Ember",
+		Model:        info.Model.String(),
+	}
+	if info.DeviceInfo.FirmwareVersion != 0 {
+		dev.SWVersion = info.DeviceInfo.Firmware().String()
+	}
+	if info.DeviceInfo.HardwareVersion != 0 {
+		dev.HWVersion = strconv.Itoa(int(info.DeviceInfo.HardwareVersion))
+	}
+
+	common := func(id, entity string) map[string]any {
+		return map[string]any{
+			"name":               entity,
+			"unique_id":          "muggo_" + b.nodeID + "_" + id,
+			"object_id":          "muggo_" + b.nodeID + "_" + id,
+			"availability_topic": b.AvailabilityTopic(),
+			"device":             dev,
+		}
+	}
+
+	state := b.topic("state")
+	configs := make(map[string]map[string]any)
+
+	climate := common("climate", "Drink")
+	climate["modes"] = []string{"heat"}
+	climate["mode_state_topic"] = state
+	climate["mode_state_template"] = "heat"
+	climate["action_topic"] = state
+	climate["action_template"] = "{{ value_json.action }}"
+	climate["current_temperature_topic"] = state
+	climate["current_temperature_template"] = "{{ value_json.drink }}"
+	climate["temperature_state_topic"] = state
+	climate["temperature_state_template"] = "{{ value_json.target }}"
+	climate["temperature_command_topic"] = b.topic("target/set")
+	climate["temperature_unit"] = "C"
+	climate["min_temp"] = mug.MinTarget.C()
+	climate["max_temp"] = mug.MaxTarget.C()
+	climate["temp_step"] = 0.5
+	configs[b.discoveryTopic("climate", "drink")] = climate
+
+	battery := common("battery", "Battery")
+	battery["state_topic"] = state
+	battery["value_template"] = "{{ value_json.battery }}"
+	battery["device_class"] = "battery"
+	battery["state_class"] = "measurement"
+	battery["unit_of_measurement"] = "%"
+	configs[b.discoveryTopic("sensor", "battery")] = battery
+
+	level := common("liquid_level", "Liquid level")
+	level["state_topic"] = state
+	level["value_template"] = "{{ value_json.liquid_level }}"
+	level["state_class"] = "measurement"
+	level["unit_of_measurement"] = "%"
+	level["icon"] = "mdi:coffee"
+	configs[b.discoveryTopic("sensor", "liquid_level")] = level
+
+	if info.Model.Capabilities().LED {
+		led := common("led", "LED")
+		led["schema"] = "json"
+		led["state_topic"] = b.topic("led")
+		led["command_topic"] = b.topic("led/set")
+		led["brightness"] = true
+		led["supported_color_modes"] = []string{"rgb"}
+		configs[b.discoveryTopic("light", "led")] = led
+	}
+
+	return configs
+}
+
+func (b *Bridge) discoveryTopic(component, object string) string {
+	return b.discovery + "/" + component + "/" + b.nodeID + "/" + object + "/config"
+}
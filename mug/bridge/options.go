@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+type Option interface {
+	apply(*Bridge) error
+}
+
+type OptionFunc func(*Bridge) error
+
+func (f OptionFunc) apply(b *Bridge) error {
+	return f(b)
+}
+
+// WithTopicPrefix sets the prefix of the state and command topics.  The
+// default is "muggo".
+func WithTopicPrefix(prefix string) Option {
+	return OptionFunc(func(b *Bridge) error {
+		prefix = strings.Trim(prefix, "/")
+		if prefix == "" {
+			return ErrInvalidInput
+		}
+		b.prefix = prefix
+		return nil
+	})
+}
+
+// WithDiscoveryPrefix sets the Home Assistant discovery prefix.  The default
+// is "homeassistant".
+func WithDiscoveryPrefix(prefix string) Option {
+	return OptionFunc(func(b *Bridge) error {
+		prefix = strings.Trim(prefix, "/")
+		if prefix == "" {
+			return ErrInvalidInput
+		}
+		b.discovery = prefix
+		return nil
+	})
+}
+
+// WithNodeID sets the id used in the topics and the Home Assistant unique
+// ids.  The default is the address of the mug without the colons, and it
+// is required if the address is not known.
+func WithNodeID(id string) Option {
+	return OptionFunc(func(b *Bridge) error {
+		if id == "" || strings.ContainsAny(id, "/+#") {
+			return ErrInvalidInput
+		}
+		b.nodeID = id
+		return nil
+	})
+}
+
+// WithTimeout sets how long to wait for the broker to acknowledge a request.
+// The default is 10 seconds.
+func WithTimeout(d time.Duration) Option {
+	return OptionFunc(func(b *Bridge) error {
+		if d <= 0 {
+			return ErrInvalidInput
+		}
+		b.timeout = d
+		return nil
+	})
+}
+
+// WithLogger sets the logger used by the bridge.  By default slog.Default()
+// is used.  A nil logger silences the bridge.
+func WithLogger(logger *slog.Logger) Option {
+	return OptionFunc(func(b *Bridge) error {
+		if logger == nil {
+			logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+		}
+		b.logger = logger
+		return nil
+	})
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package btsnoop writes ATT traffic in the btsnoop HCI log format so it can
+// be opened with Wireshark and similar tools.
+//
+// The packets are written as HCI UART (H4) frames.  Only the packets needed to
+// describe the connection and the ATT operations are synthesized, so the log
+// does not contain any discovery or link layer traffic.
+package btsnoop
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// DataLinkH4 is the btsnoop data link type for HCI UART (H4) frames.
+	DataLinkH4 = 1002
+
+	// ConnectionHandle is the HCI connection handle used for all packets.
+	ConnectionHandle = 0x0040
+)
+
+// The btsnoop timestamps are in microseconds since midnight January 1st,
+// 0 AD, so this is the offset to the unix epoch.
+const epochOffset = 0x00dcddb30f2f8000
+
+// Packet flags.
+const (
+	flagReceived = 0x01
+	flagCommand  = 0x02
+)
+
+// H4 packet types.
+const (
+	h4ACL   = 0x02
+	h4Event = 0x04
+)
+
+// ATT opcodes.
+const (
+	attErrorResponse = 0x01
+	attReadRequest   = 0x0a
+	attReadResponse  = 0x0b
+	attWriteCommand  = 0x52
+	attNotification  = 0x1b
+)
+
+// The ATT "unlikely error" code used for failed reads.
+const attUnlikelyError = 0x0e
+
+const l2capATT = 0x0004
+
+// Writer writes btsnoop records.  It is safe for concurrent use.
+type Writer struct {
+	m      sync.Mutex
+	w      io.Writer
+	now    func() time.Time
+	header bool
+}
+
+// NewWriter creates a Writer that writes the log to w.  The file header is
+// written with the first record.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w:   w,
+		now: time.Now,
+	}
+}
+
+// Connected writes a LE Connection Complete event for the peer address.  The
+// address is in the usual most significant byte first order.
+func (w *Writer) Connected(address [6]byte) error {
+	params := make([]byte, 18)
+	params[0] = 0x00 // status
+	binary.LittleEndian.PutUint16(params[1:3], ConnectionHandle)
+	params[3] = 0x00 // central
+	params[4] = 0x00 // public address
+	for i := 0; i < 6; i++ {
+		params[5+i] = address[5-i]
+	}
+
+	return w.write(event(0x3e, append([]byte{0x01}, params...)))
+}
+
+// Disconnected writes a Disconnection Complete event.
+func (w *Writer) Disconnected() error {
+	params := make([]byte, 4)
+	binary.LittleEndian.PutUint16(params[1:3], ConnectionHandle)
+	params[3] = 0x13 // remote user terminated connection
+
+	return w.write(event(0x05, params))
+}
+
+// Read writes an ATT Read Request sent to the mug followed by either the
+// Read Response or, if the read failed, an Error Response.  The pair is
+// written together so concurrent reads do not interleave.
+func (w *Writer) Read(handle uint16, value []byte, failed bool) error {
+	response := acl(true, append([]byte{attReadResponse}, value...))
+	if failed {
+		pdu := []byte{attErrorResponse, attReadRequest, 0, 0, attUnlikelyError}
+		binary.LittleEndian.PutUint16(pdu[2:4], handle)
+		response = acl(true, pdu)
+	}
+
+	return w.write(att(false, attReadRequest, handle, nil), response)
+}
+
+// WriteCommand writes an ATT Write Command (a write without response) sent to
+// the mug.
+func (w *Writer) WriteCommand(handle uint16, value []byte) error {
+	return w.write(att(false, attWriteCommand, handle, value))
+}
+
+// Notification writes an ATT Handle Value Notification received from the mug.
+func (w *Writer) Notification(handle uint16, value []byte) error {
+	return w.write(att(true, attNotification, handle, value))
+}
+
+// packet is a single H4 frame and its btsnoop flags.
+type packet struct {
+	flags uint32
+	data  []byte
+}
+
+func att(received bool, opcode byte, handle uint16, value []byte) packet {
+	pdu := make([]byte, 3, 3+len(value))
+	pdu[0] = opcode
+	binary.LittleEndian.PutUint16(pdu[1:3], handle)
+	return acl(received, append(pdu, value...))
+}
+
+// acl wraps the ATT PDU in L2CAP and HCI ACL headers.
+func acl(received bool, pdu []byte) packet {
+	pkt := make([]byte, 9, 9+len(pdu))
+	pkt[0] = h4ACL
+	// Packet boundary flag: first automatically flushable packet.
+	binary.LittleEndian.PutUint16(pkt[1:3], ConnectionHandle|0x2000)
+	binary.LittleEndian.PutUint16(pkt[3:5], uint16(4+len(pdu)))
+	binary.LittleEndian.PutUint16(pkt[5:7], uint16(len(pdu)))
+	binary.LittleEndian.PutUint16(pkt[7:9], l2capATT)
+
+	var flags uint32
+	if received {
+		flags |= flagReceived
+	}
+
+	return packet{
+		flags: flags,
+		data:  append(pkt, pdu...),
+	}
+}
+
+func event(code byte, params []byte) packet {
+	return packet{
+		flags: flagReceived | flagCommand,
+		data:  append([]byte{h4Event, code, byte(len(params))}, params...),
+	}
+}
+
+func (w *Writer) write(pkts ...packet) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if !w.header {
+		hdr := make([]byte, 16)
+		copy(hdr, "btsnoop\x00")
+		binary.BigEndian.PutUint32(hdr[8:12], 1)
+		binary.BigEndian.PutUint32(hdr[12:16], DataLinkH4)
+		if _, err := w.w.Write(hdr); err != nil {
+			return err
+		}
+		w.header = true
+	}
+
+	ts := w.now().UnixMicro() + epochOffset
+	for _, pkt := range pkts {
+		rec := make([]byte, 24, 24+len(pkt.data))
+		binary.BigEndian.PutUint32(rec[0:4], uint32(len(pkt.data)))
+		binary.BigEndian.PutUint32(rec[4:8], uint32(len(pkt.data)))
+		binary.BigEndian.PutUint32(rec[8:12], pkt.flags)
+		binary.BigEndian.PutUint32(rec[12:16], 0)
+		binary.BigEndian.PutUint64(rec[16:24], uint64(ts))
+
+		if _, err := w.w.Write(append(rec, pkt.data...)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
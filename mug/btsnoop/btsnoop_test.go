@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package btsnoop
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	flags uint32
+	ts    uint64
+	data  []byte
+}
+
+func parse(t *testing.T, buf []byte) []record {
+	t.Helper()
+
+	require.GreaterOrEqual(t, len(buf), 16)
+	require.Equal(t, []byte("btsnoop\x00"), buf[0:8])
+	require.Equal(t, uint32(1), binary.BigEndian.Uint32(buf[8:12]))
+	require.Equal(t, uint32(DataLinkH4), binary.BigEndian.Uint32(buf[12:16]))
+
+	var rv []record
+	buf = buf[16:]
+	for len(buf) > 0 {
+		require.GreaterOrEqual(t, len(buf), 24)
+		l := int(binary.BigEndian.Uint32(buf[4:8]))
+		rv = append(rv, record{
+			flags: binary.BigEndian.Uint32(buf[8:12]),
+			ts:    binary.BigEndian.Uint64(buf[16:24]),
+			data:  buf[24 : 24+l],
+		})
+		buf = buf[24+l:]
+	}
+	return rv
+}
+
+func TestWriter(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.now = func() time.Time {
+		return time.Unix(1, 0)
+	}
+
+	assert.NoError(w.Connected([6]byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66}))
+	assert.NoError(w.Read(2, []byte{0x23, 0x16}, false))
+	assert.NoError(w.Read(9, nil, true))
+	assert.NoError(w.WriteCommand(20, []byte{1, 2, 3, 4}))
+	assert.NoError(w.Notification(18, []byte{5}))
+	assert.NoError(w.Disconnected())
+
+	got := parse(t, buf.Bytes())
+	want := []record{
+		{
+			flags: 3,
+			data: []byte{0x04, 0x3e, 0x13, 0x01, 0x00, 0x40, 0x00, 0x00, 0x00,
+				0x66, 0x55, 0x44, 0x33, 0x22, 0x11,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		}, {
+			flags: 0,
+			data:  []byte{0x02, 0x40, 0x20, 0x07, 0x00, 0x03, 0x00, 0x04, 0x00, 0x0a, 0x02, 0x00},
+		}, {
+			flags: 1,
+			data:  []byte{0x02, 0x40, 0x20, 0x07, 0x00, 0x03, 0x00, 0x04, 0x00, 0x0b, 0x23, 0x16},
+		}, {
+			flags: 0,
+			data:  []byte{0x02, 0x40, 0x20, 0x07, 0x00, 0x03, 0x00, 0x04, 0x00, 0x0a, 0x09, 0x00},
+		}, {
+			flags: 1,
+			data:  []byte{0x02, 0x40, 0x20, 0x09, 0x00, 0x05, 0x00, 0x04, 0x00, 0x01, 0x0a, 0x09, 0x00, 0x0e},
+		}, {
+			flags: 0,
+			data:  []byte{0x02, 0x40, 0x20, 0x0b, 0x00, 0x07, 0x00, 0x04, 0x00, 0x52, 0x14, 0x00, 1, 2, 3, 4},
+		}, {
+			flags: 1,
+			data:  []byte{0x02, 0x40, 0x20, 0x08, 0x00, 0x04, 0x00, 0x04, 0x00, 0x1b, 0x12, 0x00, 0x05},
+		}, {
+			flags: 3,
+			data:  []byte{0x04, 0x05, 0x04, 0x00, 0x40, 0x00, 0x13},
+		},
+	}
+
+	for i := range want {
+		want[i].ts = epochOffset + 1_000_000
+	}
+	assert.Equal(want, got)
+}
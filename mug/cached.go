@@ -5,12 +5,10 @@ package mug
 
 import (
 	"time"
-
-	bt "tinygo.org/x/bluetooth"
 )
 
 type cached struct {
-	characteristic *bt.DeviceCharacteristic
+	characteristic Characteristic
 	data           []byte
 	fetched        time.Time
 	ttl            time.Duration
@@ -37,31 +35,28 @@ func (c *cached) read(now time.Time) ([]byte, error) {
 		return c.data, nil
 	}
 
-	max, err := c.characteristic.GetMTU()
-	if err != nil {
-		return nil, err
-	}
-
-	data := make([]byte, max)
-	len, err := c.characteristic.Read(data)
+	data, err := readCharacteristic(c.characteristic)
 	if err != nil {
 		return nil, err
 	}
 
-	c.data = data[:len]
+	c.data = data
 	c.fetched = now
 
 	return c.data, nil
 }
 
-func (c *cached) write(data []byte) (int, error) {
-	if c.characteristic == nil {
-		return 0, ErrNotConnected
+func readCharacteristic(char Characteristic) ([]byte, error) {
+	max, err := char.GetMTU()
+	if err != nil {
+		return nil, err
 	}
 
-	l, err := c.characteristic.WriteWithoutResponse(data)
+	data := make([]byte, max)
+	len, err := char.Read(data)
 	if err != nil {
-		c.fetched = time.Time{}
+		return nil, err
 	}
-	return l, err
+
+	return data[:len], nil
 }
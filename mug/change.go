@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Field is a field of the MugInfo.
+type Field int
+
+const (
+	FieldName Field = iota
+	FieldDrink
+	FieldTarget
+	FieldBattery
+	FieldEmpty
+	FieldLiquidLevel
+	FieldLED
+	FieldDeviceInfo
+	FieldState
+	FieldUnits
+	FieldVolume
+	FieldModel
+)
+
+var fieldStringMap = map[Field]string{
+	FieldName:        "Name",
+	FieldDrink:       "Drink",
+	FieldTarget:      "Target",
+	FieldBattery:     "Battery",
+	FieldEmpty:       "Empty",
+	FieldLiquidLevel: "LiquidLevel",
+	FieldLED:         "LED",
+	FieldDeviceInfo:  "DeviceInfo",
+	FieldState:       "State",
+	FieldUnits:       "Units",
+	FieldVolume:      "Volume",
+	FieldModel:       "Model",
+}
+
+func (f Field) String() string {
+	if rv, ok := fieldStringMap[f]; ok {
+		return rv
+	}
+
+	return fmt.Sprintf("Unknown (%d)", f)
+}
+
+// fieldValues returns the value of each field in the MugInfo.  All the values
+// are comparable.
+var fieldValues = []struct {
+	field Field
+	value func(MugInfo) any
+}{
+	{FieldName, func(mi MugInfo) any { return mi.Name }},
+	{FieldDrink, func(mi MugInfo) any { return mi.Drink }},
+	{FieldTarget, func(mi MugInfo) any { return mi.Target }},
+	{FieldBattery, func(mi MugInfo) any { return mi.Battery }},
+	{FieldEmpty, func(mi MugInfo) any { return mi.Empty }},
+	{FieldLiquidLevel, func(mi MugInfo) any { return mi.LiquidLevel }},
+	{FieldLED, func(mi MugInfo) any { return mi.LED }},
+	{FieldDeviceInfo, func(mi MugInfo) any { return mi.DeviceInfo }},
+	{FieldState, func(mi MugInfo) any { return mi.State }},
+	{FieldUnits, func(mi MugInfo) any { return mi.Units }},
+	{FieldVolume, func(mi MugInfo) any { return mi.Volume }},
+	{FieldModel, func(mi MugInfo) any { return mi.Model }},
+}
+
+// FieldChange is the previous and current value of a field that changed.
+// The values have the type of the MugInfo field, see FieldValues.
+type FieldChange struct {
+	Field    Field
+	Previous any
+	Current  any
+}
+
+// FieldValues returns the previous and current values of the change as T,
+// which must be the type of the MugInfo field.  If it is not, ok is false.
+//
+//	prev, cur, ok := mug.FieldValues[units.Temperature](fc)
+func FieldValues[T any](fc FieldChange) (prev, cur T, ok bool) {
+	prev, ok = fc.Previous.(T)
+	if !ok {
+		return prev, cur, false
+	}
+	cur, ok = fc.Current.(T)
+
+	return prev, cur, ok
+}
+
+// MugChange is the set of fields that changed between two MugInfo values.
+type MugChange struct {
+	Previous MugInfo
+	Current  MugInfo
+	Changes  []FieldChange
+}
+
+// Has returns if the field is part of the change.
+func (c MugChange) Has(f Field) bool {
+	return slices.ContainsFunc(c.Changes, func(fc FieldChange) bool {
+		return fc.Field == f
+	})
+}
+
+// diffMugInfo returns the change between the two MugInfo values.
+func diffMugInfo(prev, cur MugInfo) MugChange {
+	rv := MugChange{
+		Previous: prev,
+		Current:  cur,
+	}
+
+	for _, fv := range fieldValues {
+		p, c := fv.value(prev), fv.value(cur)
+		if p != c {
+			rv.Changes = append(rv.Changes, FieldChange{
+				Field:    fv.field,
+				Previous: p,
+				Current:  c,
+			})
+		}
+	}
+
+	return rv
+}
+
+// filter returns the change limited to the fields.  No fields means all
+// fields.
+func (c MugChange) filter(fields []Field) MugChange {
+	if len(fields) == 0 {
+		return c
+	}
+
+	rv := c
+	rv.Changes = nil
+	for _, fc := range c.Changes {
+		if slices.Contains(fields, fc.Field) {
+			rv.Changes = append(rv.Changes, fc)
+		}
+	}
+
+	return rv
+}
+
+type MugChangeListener interface {
+	MugChange(MugChange)
+}
+
+type MugChangeFunc func(MugChange)
+
+func (f MugChangeFunc) MugChange(c MugChange) {
+	f(c)
+}
+
+// fieldListener only passes along the changes to the fields of interest.
+type fieldListener struct {
+	fields   []Field
+	listener MugChangeListener
+}
+
+func (f *fieldListener) MugChange(c MugChange) {
+	c = c.filter(f.fields)
+	if len(c.Changes) > 0 {
+		f.listener.MugChange(c)
+	}
+}
+
+// AddMugChangeListener adds a listener that is called with the fields that
+// changed each time the mug information is updated.  If fields are specified,
+// the listener is only called when one of those fields changes and only
+// those fields are included in the change.
+func (m *Mug) AddMugChangeListener(l MugChangeListener, fields ...Field) CancelFunc {
+	return m.AddMugChangeListenerWithOptions(l, Fields(fields...))
+}
+
+// AddMugChangeListenerWithOptions is like AddMugChangeListener but takes the
+// options for how the changes are queued for the listener, see
+// ListenerOption.  The fields are set with the Fields option.
+func (m *Mug) AddMugChangeListenerWithOptions(l MugChangeListener, opts ...ListenerOption) CancelFunc {
+	c := newListenerConfig(opts)
+	fl := fieldListener{
+		fields:   c.fields,
+		listener: l,
+	}
+
+	return addListener(&m.mugChangeListeners, c, &m.listenerCounters, mergeMugChange, fl.MugChange)
+}
+
+// mergeMugChange combines two changes that are queued back to back.
+func mergeMugChange(older, newer MugChange) MugChange {
+	return diffMugInfo(older.Previous, newer.Current)
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_diffMugInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	prev := MugInfo{Drink: 50, Target: 57, State: Heating}
+	cur := MugInfo{Drink: 51, Target: 57, State: Perfect}
+
+	got := diffMugInfo(prev, cur)
+	assert.Equal([]FieldChange{
+		{Field: FieldDrink, Previous: units.Temperature(50), Current: units.Temperature(51)},
+		{Field: FieldState, Previous: Heating, Current: Perfect},
+	}, got.Changes)
+	assert.True(got.Has(FieldDrink))
+	assert.False(got.Has(FieldTarget))
+
+	assert.Empty(diffMugInfo(cur, cur).Changes)
+
+	filtered := got.filter([]Field{FieldState, FieldBattery})
+	assert.Equal([]FieldChange{
+		{Field: FieldState, Previous: Heating, Current: Perfect},
+	}, filtered.Changes)
+	assert.Equal(cur, filtered.Current)
+	assert.Equal(got, got.filter(nil))
+
+	assert.Equal("Drink", FieldDrink.String())
+	assert.Equal("Unknown (99)", Field(99).String())
+}
+
+func TestFieldValues(t *testing.T) {
+	assert := assert.New(t)
+
+	fc := FieldChange{Field: FieldDrink, Previous: units.Temperature(50), Current: units.Temperature(51)}
+
+	prev, cur, ok := FieldValues[units.Temperature](fc)
+	assert.True(ok)
+	assert.Equal(units.Temperature(50), prev)
+	assert.Equal(units.Temperature(51), cur)
+
+	state, _, ok := FieldValues[State](fc)
+	assert.False(ok)
+	assert.Zero(state)
+}
+
+func TestMug_AddMugChangeListener(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newIdleMug(t)
+
+	all := make(chan MugChange, 10)
+	drinks := make(chan MugChange, 10)
+	m.AddMugChangeListener(MugChangeFunc(func(c MugChange) {
+		all <- c
+	}))
+	cancel := m.AddMugChangeListener(MugChangeFunc(func(c MugChange) {
+		drinks <- c
+	}), FieldDrink)
+
+	m.setData(mugApi_DRINK, units.Temperature(55).ToMug())
+	m.setData(mugApi_TARGET, units.Temperature(57).ToMug())
+
+	// Nothing changed, so nothing is sent.
+	m.dispatch()
+
+	assert.Equal([]Field{FieldDrink}, fields(receive(t, all)))
+	assert.Equal([]Field{FieldTarget}, fields(receive(t, all)))
+	assert.Equal([]FieldChange{
+		{Field: FieldDrink, Previous: units.Temperature(0), Current: units.Temperature(55)},
+	}, receive(t, drinks).Changes)
+
+	cancel()
+	m.setData(mugApi_DRINK, units.Temperature(56).ToMug())
+	assert.Equal([]Field{FieldDrink}, fields(receive(t, all)))
+	assert.Empty(all)
+	assert.Empty(drinks)
+}
+
+func TestMug_AddMugChangeListenerWithOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newIdleMug(t)
+
+	drinks := make(chan MugChange, 10)
+	m.AddMugChangeListenerWithOptions(MugChangeFunc(func(c MugChange) {
+		drinks <- c
+	}), Fields(FieldDrink), QueueSize(1))
+
+	m.setData(mugApi_TARGET, units.Temperature(57).ToMug())
+	m.setData(mugApi_DRINK, units.Temperature(55).ToMug())
+
+	assert.Equal([]FieldChange{
+		{Field: FieldDrink, Previous: units.Temperature(0), Current: units.Temperature(55)},
+	}, receive(t, drinks).Changes)
+	assert.Empty(drinks)
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for an event")
+	}
+
+	var zero T
+	return zero
+}
+
+func fields(c MugChange) []Field {
+	var rv []Field
+	for _, fc := range c.Changes {
+		rv = append(rv, fc.Field)
+	}
+	return rv
+}
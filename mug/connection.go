@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/schmidtw/muggo/mug/event"
+)
+
+// Backoff controls how long to wait between failed connection attempts.
+type Backoff struct {
+	// Initial is the delay after the first failed attempt.
+	Initial time.Duration
+
+	// Max is the longest delay between attempts.  0 means no limit.
+	Max time.Duration
+
+	// Multiplier is how much the delay grows after each failed attempt.
+	// Values below 1 are treated as 1.
+	Multiplier float64
+
+	// Jitter is the fraction of the delay that is randomized, from 0 to 1.
+	// A jitter of 0.2 results in delays from 80% to 120% of the delay.
+	Jitter float64
+
+	// MaxAttempts is the number of failed attempts in a row before giving
+	// up.  0 means never give up.
+	MaxAttempts int
+}
+
+// Delay returns how long to wait after the specified number of failed
+// attempts in a row.
+func (b Backoff) Delay(attempt int) time.Duration {
+	return b.delay(attempt, rand.Float64())
+}
+
+// delay returns the delay using r, a number from 0 to 1, for the jitter.
+func (b Backoff) delay(attempt int, r float64) time.Duration {
+	mult := math.Max(1, b.Multiplier)
+	d := float64(b.Initial) * math.Pow(mult, float64(max(0, attempt-1)))
+	if b.Max > 0 {
+		d = math.Min(d, float64(b.Max))
+	}
+
+	jitter := math.Max(0, math.Min(1, b.Jitter))
+	d *= 1 + jitter*(2*r-1)
+
+	return time.Duration(d)
+}
+
+// WithBackoff sets the backoff used between failed connection attempts.
+func WithBackoff(b Backoff) Option {
+	return OptionFunc(func(mug *Mug) error {
+		if b.Initial < 0 || b.Max < 0 || b.MaxAttempts < 0 {
+			return ErrInvalidInput
+		}
+		mug.backoff = b
+		return nil
+	})
+}
+
+// ConnectionState returns the current connection state of the mug.
+func (m *Mug) ConnectionState() event.ConnectionState {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	return m.state
+}
+
+// AddStateChangeListener adds a listener that is called each time the
+// connection state of the mug changes.  The changes are queued for the
+// listener, see ListenerOption.  The oldest changes are dropped if the
+// listener falls behind, unless another policy is chosen.
+func (m *Mug) AddStateChangeListener(listener event.StateChangeListener, opts ...ListenerOption) CancelFunc {
+	return addListener(&m.stateListeners, newEventConfig(opts),
+		&m.listenerCounters, nil, listener.OnStateChange)
+}
+
+// stateChange moves the mug to the state in the change and tells the
+// listeners.
+func (m *Mug) stateChange(sc event.StateChange) {
+	m.m.Lock()
+	sc.Previous = m.state
+	sc.Address = m.address
+	m.state = sc.State
+	m.m.Unlock()
+
+	attrs := []any{
+		addressAttr(sc.Address),
+		"state", sc.State.String(),
+		"previous", sc.Previous.String(),
+	}
+	if sc.Err != nil {
+		attrs = append(attrs, errAttr(sc.Err))
+	}
+	if sc.State == event.Backoff {
+		attrs = append(attrs, "attempt", sc.Attempt, "delay", sc.Delay)
+	}
+	m.logger.Debug("connection state changed", attrs...)
+
+	notify(&m.stateListeners, sc)
+}
+
+func (m *Mug) setState(state event.ConnectionState, err error) {
+	m.stateChange(event.StateChange{
+		State: state,
+		Err:   err,
+	})
+}
+
+type stateReporterKey struct{}
+
+// withStateReporter returns a context that lets the transport report the
+// progress of a connection to the mug.
+func withStateReporter(ctx context.Context, fn func(event.ConnectionState, error)) context.Context {
+	return context.WithValue(ctx, stateReporterKey{}, fn)
+}
+
+// reportState reports the progress of a connection if the context has a
+// reporter.
+func reportState(ctx context.Context, state event.ConnectionState, err error) {
+	if fn, ok := ctx.Value(stateReporterKey{}).(func(event.ConnectionState, error)); ok {
+		fn(state, err)
+	}
+}
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff_delay(t *testing.T) {
+	exp := Backoff{
+		Initial:    time.Second,
+		Max:        10 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+
+	tests := []struct {
+		description string
+		backoff     Backoff
+		attempt     int
+		r           float64
+		want        time.Duration
+	}{
+		{
+			description: "first attempt",
+			backoff:     exp,
+			attempt:     1,
+			r:           0.5,
+			want:        time.Second,
+		}, {
+			description: "grows",
+			backoff:     exp,
+			attempt:     3,
+			r:           0.5,
+			want:        4 * time.Second,
+		}, {
+			description: "limited to max",
+			backoff:     exp,
+			attempt:     10,
+			r:           0.5,
+			want:        10 * time.Second,
+		}, {
+			description: "least jitter",
+			backoff:     exp,
+			attempt:     1,
+			r:           0,
+			want:        800 * time.Millisecond,
+		}, {
+			description: "most jitter",
+			backoff:     exp,
+			attempt:     1,
+			r:           1,
+			want:        1200 * time.Millisecond,
+		}, {
+			description: "fixed interval",
+			backoff:     Backoff{Initial: 5 * time.Second, Max: 5 * time.Second},
+			attempt:     4,
+			r:           1,
+			want:        5 * time.Second,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.backoff.delay(tc.attempt, tc.r))
+		})
+	}
+}
+
+func TestWithBackoff(t *testing.T) {
+	_, err := New(WithBackoff(Backoff{Initial: -1}))
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestMug_ConnectionState(t *testing.T) {
+	errRadio := errors.New("radio off")
+
+	tests := []struct {
+		description string
+		failures    int
+		maxAttempts int
+		want        []event.StateChange
+	}{
+		{
+			description: "connects after backing off",
+			failures:    2,
+			want: []event.StateChange{
+				{State: event.Scanning},
+				{State: event.Backoff, Err: errRadio, Attempt: 1},
+				{State: event.Scanning},
+				{State: event.Backoff, Err: errRadio, Attempt: 2},
+				{State: event.Scanning},
+				{State: event.Connecting},
+				{State: event.Connected},
+				{State: event.Disconnected, Err: ErrConnectionLost},
+				{State: event.Scanning},
+				{State: event.Connecting},
+				{State: event.Connected},
+				{State: event.Disconnected},
+			},
+		}, {
+			description: "gives up",
+			failures:    5,
+			maxAttempts: 3,
+			want: []event.StateChange{
+				{State: event.Scanning},
+				{State: event.Backoff, Err: errRadio, Attempt: 1},
+				{State: event.Scanning},
+				{State: event.Backoff, Err: errRadio, Attempt: 2},
+				{State: event.Scanning},
+				{State: event.Disconnected, Err: ErrMaxAttempts, Attempt: 3},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var calls int
+			var disconnected chan struct{}
+			transport := TransportFunc(func(ctx context.Context) (*Connection, error) {
+				calls++
+				if calls <= tc.failures {
+					return nil, errRadio
+				}
+				reportState(ctx, event.Connecting, nil)
+				disconnected = make(chan struct{})
+				return &Connection{
+					Characteristics: []Characteristic{
+						newFakeCharacteristic(mugApi_DRINK, 0x23, 0x16),
+					},
+					Disconnected: disconnected,
+				}, nil
+			})
+
+			changes := make(chan event.StateChange, 100)
+			m, err := New(
+				WithTransport(transport),
+				WithLogger(nil),
+				WithBackoff(Backoff{
+					Initial:     time.Millisecond,
+					MaxAttempts: tc.maxAttempts,
+				}),
+				WithStateChangeListener(event.StateChangeFunc(func(sc event.StateChange) {
+					changes <- sc
+				})),
+			)
+			require.NoError(err)
+
+			m.Start()
+			defer m.Stop()
+
+			var got []event.StateChange
+			next := func() event.StateChange {
+				select {
+				case sc := <-changes:
+					got = append(got, sc)
+					return sc
+				case <-time.After(time.Second):
+					require.FailNow("timed out waiting for a state change", "got: %v", got)
+				}
+				return event.StateChange{}
+			}
+
+			var connects int
+			for range tc.want {
+				if next().State != event.Connected {
+					continue
+				}
+
+				// The mug drops the first connection, then the mug is
+				// stopped.
+				connects++
+				if connects == 1 {
+					close(disconnected)
+				} else {
+					m.Stop()
+				}
+			}
+
+			for i, want := range tc.want {
+				assert.Equal(want.State, got[i].State, "change %d", i)
+				assert.Equal(want.Attempt, got[i].Attempt, "change %d", i)
+				if want.Err == nil {
+					assert.NoError(got[i].Err, "change %d", i)
+				} else {
+					assert.ErrorIs(got[i].Err, want.Err, "change %d", i)
+				}
+				if i > 0 {
+					assert.Equal(got[i-1].State, got[i].Previous, "change %d", i)
+				}
+			}
+			assert.Equal(tc.want[len(tc.want)-1].State, m.ConnectionState())
+		})
+	}
+}
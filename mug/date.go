@@ -4,20 +4,48 @@
 package mug
 
 import (
+	"context"
 	"encoding/binary"
 	"time"
 )
 
 type DateInfo struct {
 	UnixTime int64
-	Offset   byte // hours(DST Offset + STD Offset)
+	Offset   byte // hours(DST Offset + STD Offset) as a signed byte
+}
+
+// Time returns the DateInfo as a time in a fixed zone with the offset.
+func (d DateInfo) Time() time.Time {
+	offset := int(int8(d.Offset)) * 3600
+	return time.Unix(d.UnixTime, 0).In(time.FixedZone("", offset))
+}
+
+// dateInfo returns the DateInfo for the time t in the location loc.  The
+// offset includes any daylight saving time in effect at t.  Offsets that are
+// not a whole number of hours are truncated toward zero.
+func dateInfo(t time.Time, loc *time.Location) DateInfo {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	_, offset := t.In(loc).Zone()
+
+	return DateInfo{
+		UnixTime: t.Unix(),
+		Offset:   byte(int8(offset / 3600)),
+	}
 }
 
 func (m *Mug) Timestamp(when ...DateInfo) (*DateInfo, error) {
+	return m.TimestampContext(context.Background(), when...)
+}
+
+// TimestampContext is like Timestamp but uses the context for the request.
+func (m *Mug) TimestampContext(ctx context.Context, when ...DateInfo) (*DateInfo, error) {
 	var write [][]byte
 
 	if len(when) > 0 {
-		buf := make([]byte, 0, 5)
+		buf := make([]byte, 5)
 
 		unix := 0xffffffff & when[0].UnixTime
 		binary.LittleEndian.PutUint32(buf, uint32(unix))
@@ -26,7 +54,7 @@ func (m *Mug) Timestamp(when ...DateInfo) (*DateInfo, error) {
 		write = [][]byte{buf}
 	}
 
-	data, _, err := m.io(m, mugApi_TIME_DATE_ZONE, 5, write...)
+	data, _, err := m.io(ctx, m, mugApi_TIME_DATE_ZONE, 5, write...)
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +65,45 @@ func (m *Mug) Timestamp(when ...DateInfo) (*DateInfo, error) {
 	}, nil
 }
 
+// Clock returns the time of the mug's clock in the zone the mug was set to.
+func (m *Mug) Clock() (time.Time, error) {
+	return m.ClockContext(context.Background())
+}
+
+// ClockContext is like Clock but uses the context for the request.
+func (m *Mug) ClockContext(ctx context.Context) (time.Time, error) {
+	di, err := m.TimestampContext(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return di.Time(), nil
+}
+
+// SetClock sets the mug's clock to t in the location loc.  If loc is nil,
+// time.Local is used.
+func (m *Mug) SetClock(t time.Time, loc *time.Location) error {
+	return m.SetClockContext(context.Background(), t, loc)
+}
+
+// SetClockContext is like SetClock but uses the context for the request.
+func (m *Mug) SetClockContext(ctx context.Context, t time.Time, loc *time.Location) error {
+	_, err := m.TimestampContext(ctx, dateInfo(t, loc))
+	return err
+}
+
+// SyncClockOnConnect sets the mug's clock to the current time in the location
+// loc each time the mug connects.  If loc is nil, time.Local is used.
+func SyncClockOnConnect(loc *time.Location) Option {
+	return OptionFunc(func(mug *Mug) error {
+		if loc == nil {
+			loc = time.Local
+		}
+		mug.clockLocation = loc
+		return nil
+	})
+}
+
 func TimestampTTL(ttl time.Duration) Option {
 	return OptionFunc(func(mug *Mug) error {
 		mug.apis[mugApi_TIME_DATE_ZONE].ttl = ttl
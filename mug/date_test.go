@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dateInfo(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	winter := time.Date(2023, time.January, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2023, time.July, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description string
+		when        time.Time
+		loc         *time.Location
+		want        byte
+	}{
+		{
+			description: "utc",
+			when:        winter,
+			loc:         time.UTC,
+			want:        0x00,
+		}, {
+			description: "new york standard time",
+			when:        winter,
+			loc:         newYork,
+			want:        0xfb, // -5
+		}, {
+			description: "new york daylight saving time",
+			when:        summer,
+			loc:         newYork,
+			want:        0xfc, // -4
+		}, {
+			description: "sydney daylight saving time",
+			when:        winter,
+			loc:         sydney,
+			want:        0x0b, // +11
+		}, {
+			description: "sydney standard time",
+			when:        summer,
+			loc:         sydney,
+			want:        0x0a, // +10
+		}, {
+			description: "half hour offsets are truncated",
+			when:        summer,
+			loc:         kolkata,
+			want:        0x05,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got := dateInfo(tc.when, tc.loc)
+			assert.Equal(tc.when.Unix(), got.UnixTime)
+			assert.Equal(tc.want, got.Offset)
+
+			// The time survives the round trip.
+			assert.True(tc.when.Equal(got.Time()))
+			_, offset := got.Time().Zone()
+			assert.Equal(int(int8(tc.want))*3600, offset)
+		})
+	}
+}
+
+func TestMug_SetClock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var written []byte
+	m := &Mug{
+		io: func(_ context.Context, _ *Mug, api int, length int, write ...[]byte) ([]byte, bool, error) {
+			assert.Equal(mugApi_TIME_DATE_ZONE, api)
+			assert.Equal(5, length)
+			if len(write) > 0 {
+				written = write[0]
+			}
+			return written, false, nil
+		},
+	}
+
+	when := time.Date(2023, time.July, 15, 12, 0, 0, 0, time.UTC)
+	require.NoError(m.SetClock(when, time.FixedZone("", -4*3600)))
+	assert.Equal([]byte{0x40, 0x8a, 0xb2, 0x64, 0xfc}, written)
+
+	got, err := m.Clock()
+	require.NoError(err)
+	assert.True(when.Equal(got))
+	assert.Equal("12:00", got.UTC().Format("15:04"))
+	assert.Equal("08:00", got.Format("15:04"))
+}
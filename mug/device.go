@@ -4,25 +4,51 @@
 package mug
 
 import (
+	"context"
 	"encoding/binary"
+	"fmt"
 	"strings"
 	"time"
 )
 
 type DeviceInfo struct {
-	FirmwareVersion   uint16
+	FirmwareVersion   uint16 // See Firmware().
 	HardwareVersion   uint16
-	BootloaderVersion uint16
+	BootloaderVersion uint16 // See Bootloader().
 	SerialNumber      string
+	MugID             MugID
+}
+
+// Firmware returns the decoded firmware version.
+func (d DeviceInfo) Firmware() Version {
+	return Version(d.FirmwareVersion)
+}
+
+// Bootloader returns the decoded bootloader version.
+func (d DeviceInfo) Bootloader() Version {
+	return Version(d.BootloaderVersion)
+}
+
+// String returns a summary of the device information suitable for support
+// requests.
+func (d DeviceInfo) String() string {
+	return fmt.Sprintf("hardware %d, firmware %s, bootloader %s, serial %s, id %s",
+		d.HardwareVersion, d.Firmware(), d.Bootloader(),
+		d.SerialNumber, d.MugID)
 }
 
 func (m *Mug) DeviceInfo() (*DeviceInfo, error) {
-	data, _, err := m.io(m, mugApi_FIRMWARE_INFO, 0)
+	return m.DeviceInfoContext(context.Background())
+}
+
+// DeviceInfoContext is like DeviceInfo but uses the context for the requests.
+func (m *Mug) DeviceInfoContext(ctx context.Context) (*DeviceInfo, error) {
+	data, _, err := m.io(ctx, m, mugApi_FIRMWARE_INFO, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	serial, _, err := m.io(m, mugApi_ID, 0)
+	serial, _, err := m.io(ctx, m, mugApi_ID, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -37,10 +63,11 @@ func (m *Mug) DeviceInfo() (*DeviceInfo, error) {
 
 func deviceInfoFromData(data, serial []byte) *DeviceInfo {
 	var serialNumber string
-	if len(serial) > 6 {
-		// It's not clear what the first 6 bytes are, but they are not
-		// part of the serial number.
-		serialNumber = strings.Replace(string(serial[6:]), "-", "", -1)
+	var id MugID
+	if len(serial) >= len(id) {
+		// The first 6 bytes are the mug id, followed by the serial number.
+		copy(id[:], serial)
+		serialNumber = strings.Replace(string(serial[len(id):]), "-", "", -1)
 	}
 
 	if len(data) == 4 {
@@ -48,6 +75,7 @@ func deviceInfoFromData(data, serial []byte) *DeviceInfo {
 			FirmwareVersion: binary.LittleEndian.Uint16(data[0:2]),
 			HardwareVersion: binary.LittleEndian.Uint16(data[2:4]),
 			SerialNumber:    serialNumber,
+			MugID:           id,
 		}
 	}
 
@@ -57,6 +85,7 @@ func deviceInfoFromData(data, serial []byte) *DeviceInfo {
 			HardwareVersion:   binary.LittleEndian.Uint16(data[2:4]),
 			BootloaderVersion: binary.LittleEndian.Uint16(data[4:6]),
 			SerialNumber:      serialNumber,
+			MugID:             id,
 		}
 	}
 
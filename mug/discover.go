@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	bt "tinygo.org/x/bluetooth"
+)
+
+// Advertisement is what a mug advertises while it waits for a connection.
+type Advertisement struct {
+	// Address is the address of the mug.  Address.String() is a valid value
+	// for WithAddress().
+	Address bt.Address
+
+	// Name is the local name of the mug, if it advertised one.
+	Name string
+
+	// RSSI is the signal strength of the advertisement in dBm.
+	RSSI int16
+
+	// ServiceUUIDs are the known Ember services the mug advertised.
+	ServiceUUIDs []bt.UUID
+
+	// Model is the model inferred from the advertised services.
+	Model Model
+}
+
+// knownServiceUUIDs are the services advertised by Ember devices.
+var knownServiceUUIDs = []bt.UUID{
+	mustParseUUID(EmberCeramicMugMainServiceUUID),
+	mustParseUUID(EmberTravelMugMainServiceUUID),
+	mustParseUUID(EmberTravelMugAltMainServiceUUID),
+	mustParseUUID(EmberTravelMugPairServiceUUID),
+}
+
+func mustParseUUID(s string) bt.UUID {
+	uuid, err := bt.ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return uuid
+}
+
+// advertisementFromScan returns the advertisement for the scan result, or
+// false if the result is not from an Ember device.
+func advertisementFromScan(r bt.ScanResult) (Advertisement, bool) {
+	var uuids []bt.UUID
+	for _, uuid := range knownServiceUUIDs {
+		if r.HasServiceUUID(uuid) {
+			uuids = append(uuids, uuid)
+		}
+	}
+
+	if len(uuids) == 0 {
+		return Advertisement{}, false
+	}
+
+	return Advertisement{
+		Address:      r.Address,
+		Name:         strings.Clone(r.LocalName()),
+		RSSI:         r.RSSI,
+		ServiceUUIDs: uuids,
+		Model:        modelFromServices(uuids),
+	}, true
+}
+
+// DiscoverFunc scans for mugs until the context is done, calling fn with each
+// advertisement seen without connecting to any of them.  A mug is usually
+// seen many times.  If the adapter is nil, bt.DefaultAdapter is used.
+//
+// The adapter can only run one scan at a time, so a Mug or Fleet using the
+// same adapter should not be started while discovering.
+func DiscoverFunc(ctx context.Context, adapter *bt.Adapter, fn func(Advertisement)) error {
+	if adapter == nil {
+		adapter = bt.DefaultAdapter
+	}
+
+	if err := adapter.Enable(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- adapter.Scan(func(_ *bt.Adapter, r bt.ScanResult) {
+			if ad, ok := advertisementFromScan(r); ok {
+				fn(ad)
+			}
+		})
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	if err := adapter.StopScan(); err != nil {
+		return err
+	}
+
+	return <-done
+}
+
+// Discover scans for mugs until the context is done and returns the latest
+// advertisement from each mug found, strongest signal first.  Use a context
+// with a timeout to scan for a duration.  If the adapter is nil,
+// bt.DefaultAdapter is used.
+func Discover(ctx context.Context, adapter *bt.Adapter) ([]Advertisement, error) {
+	var (
+		m     sync.Mutex
+		found = make(map[bt.Address]Advertisement)
+	)
+
+	err := DiscoverFunc(ctx, adapter, func(ad Advertisement) {
+		m.Lock()
+		found[ad.Address] = ad
+		m.Unlock()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortAdvertisements(found), nil
+}
+
+func sortAdvertisements(found map[bt.Address]Advertisement) []Advertisement {
+	rv := make([]Advertisement, 0, len(found))
+	for _, ad := range found {
+		rv = append(rv, ad)
+	}
+
+	slices.SortFunc(rv, func(a, b Advertisement) int {
+		if a.RSSI != b.RSSI {
+			return int(b.RSSI) - int(a.RSSI)
+		}
+		return strings.Compare(a.Address.String(), b.Address.String())
+	})
+
+	return rv
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bt "tinygo.org/x/bluetooth"
+)
+
+type fakePayload struct {
+	name  string
+	uuids []bt.UUID
+}
+
+func (f *fakePayload) LocalName() string { return f.name }
+
+func (f *fakePayload) HasServiceUUID(uuid bt.UUID) bool {
+	for _, u := range f.uuids {
+		if u == uuid {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakePayload) ServiceUUIDs() []bt.UUID                        { return f.uuids }
+func (f *fakePayload) Bytes() []byte                                  { return nil }
+func (f *fakePayload) ManufacturerData() []bt.ManufacturerDataElement { return nil }
+func (f *fakePayload) ServiceData() []bt.ServiceDataElement           { return nil }
+
+func testAddress(t *testing.T, s string) bt.Address {
+	mac, err := bt.ParseMAC(s)
+	require.NoError(t, err)
+	return bt.Address{MACAddress: bt.MACAddress{MAC: mac}}
+}
+
+func Test_advertisementFromScan(t *testing.T) {
+	ceramic := mustParseUUID(EmberCeramicMugMainServiceUUID)
+	travel := mustParseUUID(EmberTravelMugAltMainServiceUUID)
+	pair := mustParseUUID(EmberTravelMugPairServiceUUID)
+	other := mustParseUUID("0000180f-0000-1000-8000-00805f9b34fb")
+
+	tests := []struct {
+		description string
+		payload     fakePayload
+		want        Advertisement
+		wantOK      bool
+	}{
+		{
+			description: "ceramic mug",
+			payload:     fakePayload{name: "Ember Ceramic Mug", uuids: []bt.UUID{other, ceramic}},
+			want: Advertisement{
+				Name:         "Ember Ceramic Mug",
+				ServiceUUIDs: []bt.UUID{ceramic},
+				Model:        ModelMug2,
+			},
+			wantOK: true,
+		}, {
+			description: "travel mug in pairing mode",
+			payload:     fakePayload{uuids: []bt.UUID{pair, travel}},
+			want: Advertisement{
+				ServiceUUIDs: []bt.UUID{travel, pair},
+				Model:        ModelTravelMug2,
+			},
+			wantOK: true,
+		}, {
+			description: "not a mug",
+			payload:     fakePayload{name: "Headphones", uuids: []bt.UUID{other}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			address := testAddress(t, "00:00:5E:00:53:01")
+			got, ok := advertisementFromScan(bt.ScanResult{
+				Address:              address,
+				RSSI:                 -60,
+				AdvertisementPayload: &tc.payload,
+			})
+
+			assert.Equal(tc.wantOK, ok)
+			if !tc.wantOK {
+				return
+			}
+
+			tc.want.Address = address
+			tc.want.RSSI = -60
+			assert.Equal(tc.want, got)
+			assert.Equal(tc.want.Model.String(), got.Model.String())
+		})
+	}
+}
+
+func Test_sortAdvertisements(t *testing.T) {
+	a := testAddress(t, "00:00:5E:00:53:01")
+	b := testAddress(t, "00:00:5E:00:53:02")
+	c := testAddress(t, "00:00:5E:00:53:03")
+
+	got := sortAdvertisements(map[bt.Address]Advertisement{
+		a: {Address: a, RSSI: -80},
+		b: {Address: b, RSSI: -40},
+		c: {Address: c, RSSI: -80},
+	})
+
+	want := []bt.Address{b, a, c}
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Equal(t, want[i], got[i].Address)
+	}
+}
+
+func TestWithAddress(t *testing.T) {
+	tests := []struct {
+		in          string
+		want        string
+		expectedErr error
+	}{
+		{
+			in:   "00:00:5E:00:53:01",
+			want: "00:00:5E:00:53:01",
+		}, {
+			in:   " 00:00:5e:00:53:01 ",
+			want: "00:00:5E:00:53:01",
+		}, {
+			in:          "not a mac",
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m, err := New(WithAddress(tc.in))
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(tc.want, m.Address().String())
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package mug
 
 import (
+	"context"
 	"time"
 
 	"github.com/schmidtw/muggo/units"
@@ -11,7 +12,12 @@ import (
 
 // Drink returns the current temperature of the drink in celsius.
 func (m *Mug) Drink() (units.Temperature, error) {
-	data, changed, err := m.io(m, mugApi_DRINK, 2)
+	return m.DrinkContext(context.Background())
+}
+
+// DrinkContext is like Drink but uses the context for the request.
+func (m *Mug) DrinkContext(ctx context.Context) (units.Temperature, error) {
+	data, changed, err := m.io(ctx, m, mugApi_DRINK, 2)
 	if err != nil {
 		return 0.0, err
 	}
@@ -31,6 +37,10 @@ func (m *Mug) drinkChanged() {
 }
 
 func drinkFromData(data []byte) units.Temperature {
+	if len(data) < 2 {
+		return 0
+	}
+
 	return units.FromMug(data)
 }
 
@@ -4,35 +4,33 @@
 package mug
 
 import (
+	"context"
 	"time"
 )
 
-// Empty returns if the mug is empty.
+// IsEmpty returns if the mug is empty.
 func (m *Mug) IsEmpty() (bool, error) {
-	data, changed, err := m.io(m, mugApi_LIQUID_LEVEL, 1)
+	return m.IsEmptyContext(context.Background())
+}
+
+// IsEmptyContext is like IsEmpty but uses the context for the request.
+func (m *Mug) IsEmptyContext(ctx context.Context) (bool, error) {
+	level, err := m.LiquidLevelContext(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	if changed {
-		m.dispatch()
-	}
-
-	return emptyFromData(data), nil
+	return level.IsEmpty(), nil
 }
 
 func (m *Mug) emptyChanged() {
 	m.m.Lock()
 	m.apis[mugApi_LIQUID_LEVEL].expire()
 	m.m.Unlock()
-	_, _ = m.IsEmpty()
-}
-
-func emptyFromData(data []byte) bool {
-	return (data[0] == 0x00)
+	_, _ = m.LiquidLevel()
 }
 
-// DrinkTempTTL sets the TTL for the temperature of the drink in the mug.
+// EmptyTTL sets the TTL for the liquid level of the mug.
 func EmptyTTL(ttl time.Duration) Option {
 	return OptionFunc(func(mug *Mug) error {
 		mug.apis[mugApi_LIQUID_LEVEL].ttl = ttl
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import bt "tinygo.org/x/bluetooth"
+
+// FleetChange is sent when a mug is added to or removed from a fleet.
+type FleetChange struct {
+	Address bt.Address
+	Added   bool
+}
+
+type FleetChangeListener interface {
+	OnFleetChange(FleetChange)
+}
+
+// FleetChangeFunc is a convenience type for implementing the
+// FleetChangeListener interface with a function.
+type FleetChangeFunc func(FleetChange)
+
+func (f FleetChangeFunc) OnFleetChange(c FleetChange) {
+	f(c)
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"fmt"
+	"time"
+
+	bt "tinygo.org/x/bluetooth"
+)
+
+// ConnectionState is where a mug is in the process of connecting.
+type ConnectionState int
+
+const (
+	Disconnected ConnectionState = iota
+	AdapterDisabled
+	Scanning
+	Connecting
+	DiscoveringServices
+	Connected
+	Backoff
+)
+
+var connectionStateStringMap = map[ConnectionState]string{
+	Disconnected:        "Disconnected",
+	AdapterDisabled:     "AdapterDisabled",
+	Scanning:            "Scanning",
+	Connecting:          "Connecting",
+	DiscoveringServices: "DiscoveringServices",
+	Connected:           "Connected",
+	Backoff:             "Backoff",
+}
+
+func (s ConnectionState) String() string {
+	if rv, ok := connectionStateStringMap[s]; ok {
+		return rv
+	}
+
+	return fmt.Sprintf("Unknown (%d)", s)
+}
+
+// StateChange is sent each time the connection state of a mug changes.
+type StateChange struct {
+	Address bt.Address
+	State   ConnectionState
+
+	// Previous is the state before this change.
+	Previous ConnectionState
+
+	// Err is the error that caused the change, if any.
+	Err error
+
+	// Attempt is the number of failed connection attempts in a row.
+	Attempt int
+
+	// Delay is how long until the next attempt when the State is Backoff.
+	Delay time.Duration
+}
+
+type StateChangeListener interface {
+	OnStateChange(StateChange)
+}
+
+// StateChangeFunc is a convenience type for implementing the
+// StateChangeListener interface with a function.
+type StateChangeFunc func(StateChange)
+
+func (f StateChangeFunc) OnStateChange(s StateChange) {
+	f(s)
+}
@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/xmidt-org/eventor"
+	bt "tinygo.org/x/bluetooth"
+)
+
+// Fleet discovers, connects to and supervises any number of mugs using a
+// single transport.  Each mug found is given its own *Mug with independent
+// caches and listeners.
+type Fleet struct {
+	m sync.Mutex
+
+	// changes serializes adding and removing mugs, so the fleet changes are
+	// sent in order.
+	changes sync.Mutex
+
+	opts []Option
+
+	transport Transport
+	backoff   Backoff
+	logger    *slog.Logger
+
+	members        map[bt.Address]*member
+	fleetListeners eventor.Eventor[event.FleetChangeListener]
+	connListeners  eventor.Eventor[event.ConnectionChangeListener]
+	stateListeners eventor.Eventor[event.StateChangeListener]
+
+	shutdown context.CancelFunc
+}
+
+// member is a mug in the fleet.
+type member struct {
+	mug *Mug
+
+	// stop stops supervising the mug's connection and drops it, and done
+	// is closed once the mug is disconnected.  They are nil while the mug
+	// is not connected.
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// NewFleet creates a new fleet.  The options provided are applied to every
+// mug the fleet creates, so listeners, TTLs and similar all work the same as
+// they do with New().  The transport and backoff are shared by the fleet,
+// and any transport wrappers are applied to each mug's connection.  The
+// transport is called again each time a mug connects, so it must be able to
+// connect to more than one mug, the way the default bluetooth transport
+// does.  The fleet never gives up, so Backoff.MaxAttempts is ignored.
+//
+// Listener options that fill in a cancel function are rejected, since the
+// function could only cancel the listener of one mug.  Use the fleet's
+// AddConnectionChangeListener() and AddStateChangeListener() instead.
+func NewFleet(opts ...Option) (*Fleet, error) {
+	// Build a template mug to validate the options and to find the shared
+	// settings.
+	template, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.cancelOptions {
+		return nil, fmt.Errorf("%w: listener options with a cancel function can't be used with a fleet", ErrInvalidInput)
+	}
+
+	f := Fleet{
+		opts:      opts,
+		transport: template.base,
+		backoff:   template.backoff,
+		logger:    template.logger,
+		members:   make(map[bt.Address]*member),
+	}
+
+	return &f, nil
+}
+
+// Start starts scanning for and connecting to mugs.
+func (f *Fleet) Start() {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if f.shutdown != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f.shutdown = cancel
+	go f.run(ctx)
+}
+
+// Stop stops the fleet from scanning for and connecting to mugs, and drops
+// the connections to the mugs.  The mugs stay part of the fleet.
+func (f *Fleet) Stop() {
+	f.m.Lock()
+	shutdown := f.shutdown
+	f.shutdown = nil
+	f.m.Unlock()
+
+	if shutdown != nil {
+		shutdown()
+	}
+}
+
+// AddFleetChangeListener adds a listener that is called when a mug is added
+// to or removed from the fleet.  A mug is added once it has connected.
+func (f *Fleet) AddFleetChangeListener(listener event.FleetChangeListener) CancelFunc {
+	return CancelFunc(f.fleetListeners.Add(listener))
+}
+
+// AddConnectionChangeListener adds a listener that is called when any mug in
+// the fleet connects or disconnects, including mugs added later.
+func (f *Fleet) AddConnectionChangeListener(listener event.ConnectionChangeListener) CancelFunc {
+	return CancelFunc(f.connListeners.Add(listener))
+}
+
+// AddStateChangeListener adds a listener that is called each time the
+// connection state of any mug in the fleet changes, including mugs added
+// later.
+func (f *Fleet) AddStateChangeListener(listener event.StateChangeListener) CancelFunc {
+	return CancelFunc(f.stateListeners.Add(listener))
+}
+
+// Mugs returns the mugs that are part of the fleet.
+func (f *Fleet) Mugs() []*Mug {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	rv := make([]*Mug, 0, len(f.members))
+	for _, mem := range f.members {
+		rv = append(rv, mem.mug)
+	}
+	return rv
+}
+
+// Mug returns the mug with the specified address or nil if the mug is not
+// part of the fleet.
+func (f *Fleet) Mug(address bt.Address) *Mug {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if mem, ok := f.members[address]; ok {
+		return mem.mug
+	}
+	return nil
+}
+
+// Remove disconnects and removes the mug with the specified address from the
+// fleet.  If the mug is seen again it will be added back to the fleet as a new
+// mug.
+func (f *Fleet) Remove(address bt.Address) {
+	f.changes.Lock()
+	defer f.changes.Unlock()
+
+	f.m.Lock()
+	mem, ok := f.members[address]
+	delete(f.members, address)
+	var stop context.CancelFunc
+	var done chan struct{}
+	if ok {
+		stop, done = mem.stop, mem.done
+	}
+	f.m.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if stop != nil {
+		stop()
+		<-done
+	}
+	f.notifyFleetChange(address, false)
+}
+
+func (f *Fleet) run(ctx context.Context) {
+	var attempt int
+	for {
+		conn, err := f.transport.Connect(ctx)
+		if err == nil {
+			attempt = 0
+			f.add(ctx, conn)
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		attempt++
+		delay := f.backoff.Delay(attempt)
+		f.logger.Warn("unable to connect to mug", errAttr(err),
+			slog.Int("attempt", attempt), slog.Duration("retry", delay))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// add connects the mug, adding it to the fleet if it is new, and supervises
+// the connection until either the mug disconnects, the mug is removed or
+// the fleet is stopped.
+func (f *Fleet) add(ctx context.Context, conn *Connection) {
+	f.changes.Lock()
+	defer f.changes.Unlock()
+
+	address := conn.Address
+	logger := f.logger.With(addressAttr(address))
+
+	f.m.Lock()
+	mem, found := f.members[address]
+	f.m.Unlock()
+
+	if !found {
+		// The options were validated by NewFleet(), so this can't fail.
+		mug, _ := New(f.opts...)
+		mug.address = address
+		mug.managed = true
+		mug.AddConnectionChangeListener(event.ConnectionChangeFunc(f.notifyConnectionChange))
+		mug.AddStateChangeListener(event.StateChangeFunc(f.notifyStateChange))
+		mem = &member{mug: mug}
+	}
+	mug := mem.mug
+
+	// Pass the connection through any transport wrappers the mug has.
+	conn, err := mug.wrap(TransportFunc(
+		func(context.Context) (*Connection, error) {
+			return conn, nil
+		})).Connect(ctx)
+	if err != nil {
+		logger.Warn("unable to connect to mug", errAttr(err))
+		mug.setState(event.Disconnected, err)
+		return
+	}
+
+	mug.connect(conn)
+
+	stopCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	f.m.Lock()
+	mem.stop = stop
+	mem.done = done
+	f.members[address] = mem
+	f.m.Unlock()
+
+	if !found {
+		f.notifyFleetChange(address, true)
+	}
+
+	go func() {
+		defer close(done)
+		defer stop()
+
+		var reason error
+		select {
+		case <-stopCtx.Done():
+			// The mug was removed or the fleet stopped, so the mug is
+			// still connected.
+			if conn.Disconnect != nil {
+				if err := conn.Disconnect(); err != nil {
+					logger.Warn("unable to disconnect from mug", errAttr(err))
+				}
+			}
+		case <-conn.Disconnected:
+			reason = ErrConnectionLost
+		}
+
+		f.m.Lock()
+		if mem.done == done {
+			mem.stop = nil
+			mem.done = nil
+		}
+		f.m.Unlock()
+
+		mug.disconnect(reason)
+	}()
+}
+
+func (f *Fleet) notifyFleetChange(address bt.Address, added bool) {
+	fc := event.FleetChange{
+		Address: address,
+		Added:   added,
+	}
+	f.fleetListeners.Visit(func(listener event.FleetChangeListener) {
+		listener.OnFleetChange(fc)
+	})
+}
+
+func (f *Fleet) notifyConnectionChange(cc event.ConnectionChange) {
+	f.connListeners.Visit(func(listener event.ConnectionChangeListener) {
+		listener.OnConnectionChange(cc)
+	})
+}
+
+func (f *Fleet) notifyStateChange(sc event.StateChange) {
+	f.stateListeners.Visit(func(listener event.StateChangeListener) {
+		listener.OnStateChange(sc)
+	})
+}
@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stuckCharacteristic never finishes reading until it is released.
+type stuckCharacteristic struct {
+	*fakeCharacteristic
+	release chan struct{}
+}
+
+func (s *stuckCharacteristic) Read(data []byte) (int, error) {
+	<-s.release
+	return s.fakeCharacteristic.Read(data)
+}
+
+func TestLockedIO_Context(t *testing.T) {
+	tests := []struct {
+		description string
+		timeout     time.Duration
+		ctx         func() (context.Context, context.CancelFunc)
+		expectedErr []error
+	}{
+		{
+			description: "the context deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 20*time.Millisecond)
+			},
+			expectedErr: []error{ErrTimeout, context.DeadlineExceeded},
+		}, {
+			description: "the io timeout",
+			timeout:     20 * time.Millisecond,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.Background(), func() {}
+			},
+			expectedErr: []error{ErrTimeout},
+		}, {
+			description: "cancelled",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				go func() {
+					time.Sleep(20 * time.Millisecond)
+					cancel()
+				}()
+				return ctx, cancel
+			},
+			expectedErr: []error{context.Canceled},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m, err := New(
+				WithTransport(TransportFunc(func(ctx context.Context) (*Connection, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				})),
+				IOTimeout(tc.timeout),
+			)
+			require.NoError(err)
+
+			stuck := &stuckCharacteristic{
+				fakeCharacteristic: newFakeCharacteristic(mugApi_DRINK, 0x10, 0x16),
+				release:            make(chan struct{}),
+			}
+			defer close(stuck.release)
+			m.apis[mugApi_DRINK].characteristic = stuck
+
+			ctx, cancel := tc.ctx()
+			defer cancel()
+
+			_, err = m.DrinkContext(ctx)
+			for _, want := range tc.expectedErr {
+				assert.ErrorIs(err, want)
+			}
+			assert.Equal(IOStats{ReadErrors: 1}, m.IOStats())
+
+			// The stuck read does not block the rest of the mug.
+			done := make(chan struct{})
+			go func() {
+				_ = m.All()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				require.FailNow("All() was blocked by the stuck read")
+			}
+		})
+	}
+}
+
+func TestLockedIO_Write(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	m, err := New(WithTransport(TransportFunc(func(ctx context.Context) (*Connection, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})))
+	require.NoError(err)
+
+	_, err = m.NameContext(context.Background())
+	assert.ErrorIs(err, ErrNotConnected)
+
+	m.apis[mugApi_NAME].characteristic = newFakeCharacteristic(mugApi_NAME, []byte("Mug")...)
+
+	got, err := m.NameContext(context.Background())
+	require.NoError(err)
+	assert.Equal("Mug", got)
+
+	// A write is always followed by a fresh read, even if the value is cached.
+	got, err = m.NameContext(context.Background(), "Cup")
+	require.NoError(err)
+	assert.Equal("Cup", got)
+	assert.Equal("Cup", m.All().Name)
+
+	// The read back after the write is counted as a read.
+	assert.Equal(IOStats{Reads: 2, Writes: 1}, m.IOStats())
+}
+
+// failingCharacteristic fails the reads and, if writeErr is set, the writes.
+type failingCharacteristic struct {
+	*fakeCharacteristic
+	writeErr bool
+}
+
+func (f *failingCharacteristic) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func (f *failingCharacteristic) WriteWithoutResponse(p []byte) (int, error) {
+	if f.writeErr {
+		return 0, errors.New("write failed")
+	}
+	return f.fakeCharacteristic.WriteWithoutResponse(p)
+}
+
+func TestLockedIO_errorStats(t *testing.T) {
+	tests := []struct {
+		description string
+		writeErr    bool
+		want        IOStats
+	}{
+		{
+			description: "the read back fails",
+			want:        IOStats{Writes: 1, ReadErrors: 1},
+		}, {
+			description: "the write fails",
+			writeErr:    true,
+			want:        IOStats{WriteErrors: 1},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m, err := New(WithTransport(TransportFunc(func(ctx context.Context) (*Connection, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})))
+			require.NoError(err)
+
+			m.apis[mugApi_NAME].characteristic = &failingCharacteristic{
+				fakeCharacteristic: newFakeCharacteristic(mugApi_NAME),
+				writeErr:           tc.writeErr,
+			}
+
+			_, err = m.NameContext(context.Background(), "Cup")
+			assert.Error(err)
+			assert.Equal(tc.want, m.IOStats())
+		})
+	}
+}
+
+func TestLockedIO_chargingRace(t *testing.T) {
+	require := require.New(t)
+
+	m, err := New(
+		WithTransport(TransportFunc(func(ctx context.Context) (*Connection, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})),
+		BatteryTTL(time.Hour),
+	)
+	require.NoError(err)
+
+	m.apis[mugApi_BATTERY].characteristic = newFakeCharacteristic(mugApi_BATTERY, 50, 0, 0x10, 0x16, 0)
+	_, err = m.BatteryInfoContext(context.Background())
+	require.NoError(err)
+
+	// The charging events update the cached data while it is being read,
+	// which the race detector reports if the data is changed in place.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			m.charging()
+			m.discharging()
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		_, err := m.BatteryInfoContext(context.Background())
+		require.NoError(err)
+	}
+	<-done
+}
+
+func TestMug_connectStuckRead(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	m, err := New(
+		WithTransport(TransportFunc(func(ctx context.Context) (*Connection, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})),
+		WithLogger(nil),
+		IOTimeout(50*time.Millisecond),
+	)
+	require.NoError(err)
+
+	stuck := &stuckCharacteristic{
+		fakeCharacteristic: newFakeCharacteristic(mugApi_DRINK, 0x10, 0x16),
+		release:            make(chan struct{}),
+	}
+	defer close(stuck.release)
+
+	connected := make(chan struct{})
+	go func() {
+		defer close(connected)
+		m.connect(&Connection{
+			Characteristics: []Characteristic{
+				stuck,
+				newFakeCharacteristic(mugApi_NAME, []byte("Mug")...),
+			},
+			Disconnected: make(chan struct{}),
+		})
+	}()
+
+	// The stuck read does not block the rest of the mug.
+	done := make(chan struct{})
+	go func() {
+		_ = m.All()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow("All() was blocked by the stuck initial read")
+	}
+
+	// The connection goes ahead once the reads time out.
+	select {
+	case <-connected:
+	case <-time.After(time.Second):
+		require.FailNow("the stuck initial read held up the connection")
+	}
+	assert.True(m.IsConnected())
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import "sync/atomic"
+
+// IOStats are the totals for the reads and writes made to the mug's
+// characteristics.  Reads answered from the cache are not counted.  A write
+// is followed by a read of the value the mug took, which is counted as a
+// read.
+type IOStats struct {
+	Reads       uint64
+	Writes      uint64
+	ReadErrors  uint64
+	WriteErrors uint64
+}
+
+type ioCounters struct {
+	reads       atomic.Uint64
+	writes      atomic.Uint64
+	readErrors  atomic.Uint64
+	writeErrors atomic.Uint64
+}
+
+// count records the result of a read or, if write is set, a write.
+func (c *ioCounters) count(write bool, err error) {
+	switch {
+	case write && err != nil:
+		c.writeErrors.Add(1)
+	case write:
+		c.writes.Add(1)
+	case err != nil:
+		c.readErrors.Add(1)
+	default:
+		c.reads.Add(1)
+	}
+}
+
+// countExchange records the result of an exchange with the mug, which is a
+// read or, if write is set, a write followed by a read.  The error is from
+// the write unless wrote is set.
+func (c *ioCounters) countExchange(write, wrote bool, err error) {
+	if write && !wrote {
+		c.count(true, err)
+		return
+	}
+	if write {
+		c.count(true, nil)
+	}
+	c.count(false, err)
+}
+
+// IOStats returns the totals for the reads and writes made to the mug.
+func (m *Mug) IOStats() IOStats {
+	return IOStats{
+		Reads:       m.ioCounters.reads.Load(),
+		Writes:      m.ioCounters.writes.Load(),
+		ReadErrors:  m.ioCounters.readErrors.Load(),
+		WriteErrors: m.ioCounters.writeErrors.Load(),
+	}
+}
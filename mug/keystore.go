@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	bt "tinygo.org/x/bluetooth"
+)
+
+// MemoryKeyStore keeps the pairing keys in memory.
+type MemoryKeyStore struct {
+	m    sync.Mutex
+	keys map[bt.Address]Key
+}
+
+var _ KeyStore = (*MemoryKeyStore)(nil)
+
+func (s *MemoryKeyStore) Load(address bt.Address) (Key, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	key, ok := s.keys[address]
+	if !ok {
+		return Key{}, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+func (s *MemoryKeyStore) Save(address bt.Address, key Key) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.keys == nil {
+		s.keys = make(map[bt.Address]Key)
+	}
+	s.keys[address] = key
+	return nil
+}
+
+// FileKeyStore keeps the pairing keys in a JSON file.  The file is only
+// readable by the owner since the keys allow writing to the mugs.
+type FileKeyStore struct {
+	m    sync.Mutex
+	path string
+}
+
+var _ KeyStore = (*FileKeyStore)(nil)
+
+// NewFileKeyStore creates a key store that uses the file at path.  The file
+// is created when the first key is saved.
+func NewFileKeyStore(path string) *FileKeyStore {
+	return &FileKeyStore{
+		path: path,
+	}
+}
+
+func (s *FileKeyStore) Load(address bt.Address) (Key, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	keys, err := s.read()
+	if err != nil {
+		return Key{}, err
+	}
+
+	key, ok := keys[address.String()]
+	if !ok {
+		return Key{}, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+func (s *FileKeyStore) Save(address bt.Address, key Key) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	keys, err := s.read()
+	if err != nil {
+		return err
+	}
+	keys[address.String()] = key
+
+	buf, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a failure doesn't lose the keys.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *FileKeyStore) read() (map[string]Key, error) {
+	keys := make(map[string]Key)
+
+	buf, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return keys, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(buf, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyStores(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+
+	tests := []struct {
+		description string
+		store       KeyStore
+	}{
+		{
+			description: "memory",
+			store:       &MemoryKeyStore{},
+		}, {
+			description: "file",
+			store:       NewFileKeyStore(path),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			a := testAddress(t, "00:00:5E:00:53:01")
+			b := testAddress(t, "00:00:5E:00:53:02")
+
+			_, err := tc.store.Load(a)
+			assert.ErrorIs(err, ErrKeyNotFound)
+
+			key := Key{Device: []byte{1, 2}, User: []byte{3, 4}}
+			require.NoError(tc.store.Save(a, key))
+			require.NoError(tc.store.Save(b, Key{User: []byte{5}}))
+
+			got, err := tc.store.Load(a)
+			require.NoError(err)
+			assert.Equal(key, got)
+		})
+	}
+
+	// The file keeps the keys between stores and is private.
+	got, err := NewFileKeyStore(path).Load(testAddress(t, "00:00:5E:00:53:02"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{5}, got.User)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
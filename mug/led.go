@@ -4,18 +4,24 @@
 package mug
 
 import (
+	"context"
 	"image/color"
 	"time"
 )
 
 func (m *Mug) Led(rgba ...color.NRGBA) (*color.NRGBA, error) {
+	return m.LedContext(context.Background(), rgba...)
+}
+
+// LedContext is like Led but uses the context for the request.
+func (m *Mug) LedContext(ctx context.Context, rgba ...color.NRGBA) (*color.NRGBA, error) {
 	var write [][]byte
 
 	if len(rgba) > 0 {
 		write = [][]byte{[]byte{rgba[0].R, rgba[0].G, rgba[0].B, rgba[0].A}}
 	}
 
-	data, _, err := m.io(m, mugApi_LED, 4, write...)
+	data, _, err := m.io(ctx, m, mugApi_LED, 4, write...)
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +32,10 @@ func (m *Mug) Led(rgba ...color.NRGBA) (*color.NRGBA, error) {
 }
 
 func ledFromData(data []byte) color.NRGBA {
+	if len(data) < 4 {
+		return color.NRGBA{}
+	}
+
 	return color.NRGBA{
 		R: data[0],
 		G: data[1],
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import "context"
+
+// FullLiquidLevel is the liquid level the mug reports when it is full.
+const FullLiquidLevel = 30
+
+// LiquidLevel is how much liquid is in the mug.
+type LiquidLevel struct {
+	// Raw is the level reported by the mug from 0 (empty) to FullLiquidLevel.
+	Raw byte
+
+	// Percent is the level from 0 (empty) to 100 (full).
+	Percent float64
+}
+
+// IsEmpty returns if the mug is empty.
+func (l LiquidLevel) IsEmpty() bool {
+	return l.Raw == 0
+}
+
+// LiquidLevel returns how much liquid is in the mug.
+func (m *Mug) LiquidLevel() (LiquidLevel, error) {
+	return m.LiquidLevelContext(context.Background())
+}
+
+// LiquidLevelContext is like LiquidLevel but uses the context for the request.
+func (m *Mug) LiquidLevelContext(ctx context.Context) (LiquidLevel, error) {
+	data, changed, err := m.io(ctx, m, mugApi_LIQUID_LEVEL, 1)
+	if err != nil {
+		return LiquidLevel{}, err
+	}
+
+	if changed {
+		m.dispatch()
+	}
+
+	return liquidLevelFromData(data), nil
+}
+
+func liquidLevelFromData(data []byte) LiquidLevel {
+	if len(data) < 1 {
+		return LiquidLevel{}
+	}
+
+	raw := min(data[0], FullLiquidLevel)
+
+	return LiquidLevel{
+		Raw:     raw,
+		Percent: float64(raw) * 100 / FullLiquidLevel,
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_liquidLevelFromData(t *testing.T) {
+	tests := []struct {
+		data  []byte
+		want  LiquidLevel
+		empty bool
+	}{
+		{
+			data:  []byte{},
+			want:  LiquidLevel{},
+			empty: true,
+		}, {
+			data:  []byte{0x00},
+			want:  LiquidLevel{},
+			empty: true,
+		}, {
+			data: []byte{0x0f},
+			want: LiquidLevel{Raw: 15, Percent: 50},
+		}, {
+			data: []byte{0x1e},
+			want: LiquidLevel{Raw: 30, Percent: 100},
+		}, {
+			data: []byte{0xff},
+			want: LiquidLevel{Raw: 30, Percent: 100},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%x", tc.data), func(t *testing.T) {
+			assert := assert.New(t)
+
+			got := liquidLevelFromData(tc.data)
+			assert.Equal(tc.want, got)
+			assert.Equal(tc.empty, got.IsEmpty())
+		})
+	}
+}
+
+func TestMug_All_empty(t *testing.T) {
+	tests := []struct {
+		description string
+		data        []byte
+		want        bool
+	}{
+		{description: "unknown", data: nil, want: true},
+		{description: "empty", data: []byte{0x00}, want: true},
+		{description: "half full", data: []byte{0x0f}, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m, err := New()
+			assert.NoError(err)
+			m.apis[mugApi_LIQUID_LEVEL].data = tc.data
+
+			info := m.All()
+			assert.Equal(tc.want, info.Empty)
+			assert.Equal(info.LiquidLevel.IsEmpty(), info.Empty)
+		})
+	}
+}
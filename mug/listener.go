@@ -10,15 +10,18 @@ import (
 )
 
 type MugInfo struct {
-	Name       string
-	Drink      units.Temperature
-	Target     units.Temperature
-	Battery    BatteryInfo
-	Empty      bool
-	LED        color.NRGBA
-	DeviceInfo DeviceInfo
-	State      State
-	Units      units.TemperatureUnit
+	Name        string
+	Drink       units.Temperature
+	Target      units.Temperature
+	Battery     BatteryInfo
+	Empty       bool
+	LiquidLevel LiquidLevel
+	LED         color.NRGBA
+	DeviceInfo  DeviceInfo
+	State       State
+	Units       units.TemperatureUnit
+	Volume      units.Volume
+	Model       Model
 }
 
 type MugListener interface {
@@ -27,9 +30,11 @@ type MugListener interface {
 
 type CancelFunc func()
 
-func (m *Mug) AddMugListener(l MugListener) CancelFunc {
-	cancel := m.mugListeners.Add(l)
-	return CancelFunc(cancel)
+// AddMugListener adds a listener that is called with the mug information each
+// time it is updated.  The updates are queued for the listener, see
+// ListenerOption.
+func (m *Mug) AddMugListener(l MugListener, opts ...ListenerOption) CancelFunc {
+	return addListener(&m.mugListeners, newListenerConfig(opts), &m.listenerCounters, nil, l.MugInfo)
 }
 
 type MugListenerFunc func(MugInfo)
@@ -50,23 +55,39 @@ func (m *Mug) All() MugInfo {
 		di = &DeviceInfo{}
 	}
 
+	// Empty comes from the liquid level so the two always agree.
+	level := liquidLevelFromData(m.apis[mugApi_LIQUID_LEVEL].data)
+
 	return MugInfo{
-		Name:       nameFromData(m.apis[mugApi_NAME].data),
-		Drink:      drinkFromData(m.apis[mugApi_DRINK].data),
-		Target:     targetFromData(m.apis[mugApi_TARGET].data),
-		Battery:    batteryInfoFromData(m.apis[mugApi_BATTERY].data),
-		Empty:      emptyFromData(m.apis[mugApi_LIQUID_LEVEL].data),
-		LED:        ledFromData(m.apis[mugApi_LED].data),
-		DeviceInfo: *di,
-		State:      stateFromData(m.apis[mugApi_STATE].data),
-		Units:      unitsFromData(m.apis[mugApi_UNITS].data),
+		Name:        nameFromData(m.apis[mugApi_NAME].data),
+		Drink:       drinkFromData(m.apis[mugApi_DRINK].data),
+		Target:      targetFromData(m.apis[mugApi_TARGET].data),
+		Battery:     batteryInfoFromData(m.apis[mugApi_BATTERY].data),
+		Empty:       level.IsEmpty(),
+		LiquidLevel: level,
+		LED:         ledFromData(m.apis[mugApi_LED].data),
+		DeviceInfo:  *di,
+		State:       stateFromData(m.apis[mugApi_STATE].data),
+		Units:       unitsFromData(m.apis[mugApi_UNITS].data),
+		Volume:      volumeFromData(m.apis[mugApi_VOLUME].data),
+		Model:       m.model(),
 	}
 }
 
 func (m *Mug) dispatch() {
+	// The dispatches are serialized so the changes are reported in order.
+	m.dispatchM.Lock()
+	defer m.dispatchM.Unlock()
+
 	mi := m.All()
 
-	m.mugListeners.Visit(func(l MugListener) {
-		l.MugInfo(mi)
-	})
+	notify(&m.mugListeners, mi)
+
+	change := diffMugInfo(m.last, mi)
+	m.last = mi
+	if len(change.Changes) == 0 {
+		return
+	}
+
+	notify(&m.mugChangeListeners, change)
 }
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"encoding/hex"
+	"io"
+	"log/slog"
+
+	bt "tinygo.org/x/bluetooth"
+)
+
+// WithLogger sets the logger used by the mug.  By default slog.Default() is
+// used.  A nil logger silences the mug.
+func WithLogger(logger *slog.Logger) Option {
+	return OptionFunc(func(mug *Mug) error {
+		if logger == nil {
+			logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+		}
+		mug.logger = logger
+		return nil
+	})
+}
+
+func addressAttr(address bt.Address) slog.Attr {
+	return slog.String("address", address.String())
+}
+
+func idAttr(id int) slog.Attr {
+	return slog.Int("id", id)
+}
+
+func dataAttr(data []byte) slog.Attr {
+	return slog.String("data", hex.EncodeToString(data))
+}
+
+func errAttr(err error) slog.Attr {
+	return slog.Any("err", err)
+}
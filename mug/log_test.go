@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bt "tinygo.org/x/bluetooth"
+)
+
+type syncBuffer struct {
+	m   sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) records(t *testing.T) []map[string]any {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var rv []map[string]any
+	dec := json.NewDecoder(bytes.NewReader(s.buf.Bytes()))
+	for dec.More() {
+		var rec map[string]any
+		require.NoError(t, dec.Decode(&rec))
+		rv = append(rv, rec)
+	}
+	return rv
+}
+
+func TestWithLogger(t *testing.T) {
+	address := bt.Address{MACAddress: bt.MACAddress{MAC: bt.MAC{0x01, 0x53, 0x00, 0x5e, 0x00, 0x00}}}
+	push := newFakeCharacteristic(mugApi_PUSH_EVENT)
+
+	tests := []struct {
+		description string
+		logger      func(*syncBuffer) *slog.Logger
+		want        []map[string]any
+	}{
+		{
+			description: "json",
+			logger: func(buf *syncBuffer) *slog.Logger {
+				return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			},
+			want: []map[string]any{
+				{"msg": "initial read", "address": "00:00:5E:00:53:01", "id": float64(mugApi_DRINK), "data": "2316"},
+				{"msg": "mug connected", "address": "00:00:5E:00:53:01", "characteristics": float64(2)},
+				{"msg": "push event", "address": "00:00:5E:00:53:01", "type": "DrinkChanged", "data": "05"},
+				{"msg": "characteristic read", "address": "00:00:5E:00:53:01", "id": float64(mugApi_DRINK), "data": "2316"},
+				{"msg": "mug disconnected", "address": "00:00:5E:00:53:01"},
+			},
+		}, {
+			description: "silenced",
+			logger: func(*syncBuffer) *slog.Logger {
+				return nil
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			disconnected := make(chan struct{})
+			calls := 0
+			transport := TransportFunc(func(ctx context.Context) (*Connection, error) {
+				calls++
+				if calls > 1 {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
+				return &Connection{
+					Address: address,
+					Characteristics: []Characteristic{
+						newFakeCharacteristic(mugApi_DRINK, 0x23, 0x16),
+						push,
+					},
+					Disconnected: disconnected,
+				}, nil
+			})
+
+			var buf syncBuffer
+			connected := make(chan bool, 2)
+			m, err := New(
+				WithTransport(transport),
+				WithLogger(tc.logger(&buf)),
+				WithChangeConnectionListener(
+					event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+						connected <- cc.Connected
+					})),
+			)
+			require.NoError(err)
+
+			m.Start()
+			defer m.Stop()
+
+			require.True(<-connected)
+
+			m.onPushEvent([]byte{NOTIFY_DRINK_CHANGED})
+			m.apis[mugApi_DRINK].expire()
+			_, err = m.Drink()
+			require.NoError(err)
+
+			close(disconnected)
+			select {
+			case got := <-connected:
+				require.False(got)
+			case <-time.After(time.Second):
+				require.FailNow("timed out waiting to disconnect")
+			}
+
+			got := buf.records(t)
+			if tc.want == nil {
+				assert.Empty(got)
+				return
+			}
+
+			// Each wanted record is found, in order, with its attributes.
+			i := 0
+			for _, rec := range got {
+				if i < len(tc.want) && matches(rec, tc.want[i]) {
+					i++
+				}
+			}
+			assert.Equal(len(tc.want), i, "missing %v in %v", tc.want[min(i, len(tc.want)-1)], got)
+		})
+	}
+}
+
+func matches(rec, want map[string]any) bool {
+	for k, v := range want {
+		if rec[k] != v {
+			return false
+		}
+	}
+	return true
+}
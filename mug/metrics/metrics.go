@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics exports the telemetry of mugs as Prometheus metrics.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+)
+
+const namespace = "muggo"
+
+var (
+	drinkDesc = newDesc("drink_temperature_celsius",
+		"The temperature of the drink.")
+	targetDesc = newDesc("target_temperature_celsius",
+		"The target temperature of the drink.")
+	batteryDesc = newDesc("battery_percent",
+		"The battery charge left.")
+	batteryTempDesc = newDesc("battery_temperature_celsius",
+		"The temperature of the battery.")
+	chargingDesc = newDesc("battery_charging",
+		"1 if the battery is charging, 0 otherwise.")
+	liquidLevelDesc = newDesc("liquid_level_percent",
+		"How full the mug is.")
+	stateDesc = newDesc("state",
+		"1 for the current state of the mug, 0 for the others.", "state")
+	connectedDesc = newDesc("connected",
+		"1 if the mug is connected, 0 otherwise.")
+	connectsDesc = newDesc("connects_total",
+		"The number of times the mug connected.")
+	disconnectsDesc = newDesc("disconnects_total",
+		"The number of times the mug disconnected.")
+	readErrorsDesc = newDesc("ble_read_errors_total",
+		"The number of characteristic reads that failed.")
+	writeErrorsDesc = newDesc("ble_write_errors_total",
+		"The number of characteristic writes that failed.")
+	pushEventsDesc = newDesc("push_events_total",
+		"The number of push events from the mug by NOTIFY_* code.", "code", "event")
+)
+
+func newDesc(name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help,
+		append([]string{"address"}, labels...), nil)
+}
+
+// Exporter is a prometheus.Collector for the telemetry of the mugs it
+// watches.
+type Exporter struct {
+	m    sync.Mutex
+	mugs map[*mug.Mug]*watched
+}
+
+var _ prometheus.Collector = (*Exporter)(nil)
+
+type watched struct {
+	refs        int
+	cancel      func()
+	info        mug.MugInfo
+	valid       bool
+	connected   bool
+	connects    uint64
+	disconnects uint64
+	push        map[byte]*pushCount
+}
+
+type pushCount struct {
+	name  string
+	count uint64
+}
+
+// New creates an Exporter that isn't watching any mugs.
+func New() *Exporter {
+	return &Exporter{
+		mugs: make(map[*mug.Mug]*watched),
+	}
+}
+
+// Watch starts exporting the telemetry of the mug.  The returned function
+// stops watching the mug and removes its metrics.  Watching a mug that is
+// already watched shares the existing metrics, which are removed once every
+// returned function has been called.  The metrics of a mug are only
+// exported once its address is known.
+func (e *Exporter) Watch(m *mug.Mug) mug.CancelFunc {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	w, ok := e.mugs[m]
+	if !ok {
+		w = e.watch(m)
+		e.mugs[m] = w
+	}
+	w.refs++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			e.m.Lock()
+			w.refs--
+			done := w.refs == 0
+			if done {
+				delete(e.mugs, m)
+			}
+			e.m.Unlock()
+
+			if done {
+				w.cancel()
+			}
+		})
+	}
+}
+
+// watch adds the listeners that keep the metrics of the mug up to date.
+func (e *Exporter) watch(m *mug.Mug) *watched {
+	w := watched{
+		push: make(map[byte]*pushCount),
+	}
+
+	// Only the latest information matters, so a single slot is plenty.
+	cancelInfo := m.AddMugListener(mug.MugListenerFunc(func(info mug.MugInfo) {
+		e.m.Lock()
+		defer e.m.Unlock()
+
+		// Information delivered after the mug disconnected is stale.
+		if !m.IsConnected() {
+			return
+		}
+
+		w.info = info
+		w.valid = true
+	}), mug.QueueSize(1))
+
+	cancelConn := m.AddConnectionChangeListener(event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+		e.m.Lock()
+		defer e.m.Unlock()
+
+		w.connected = cc.Connected
+		if cc.Connected {
+			w.connects++
+			return
+		}
+
+		// Nothing is known about a disconnected mug.
+		w.disconnects++
+		w.info = mug.MugInfo{}
+		w.valid = false
+	}))
+
+	cancelPush := m.AddPushEventListener(mug.PushEventFunc(func(pe mug.PushEvent) {
+		if len(pe.Raw) == 0 {
+			return
+		}
+
+		e.m.Lock()
+		defer e.m.Unlock()
+
+		pc, ok := w.push[pe.Raw[0]]
+		if !ok {
+			pc = &pushCount{name: pe.Type.String()}
+			w.push[pe.Raw[0]] = pc
+		}
+		pc.count++
+	}))
+
+	w.cancel = func() {
+		cancelInfo()
+		cancelConn()
+		cancelPush()
+	}
+
+	return &w
+}
+
+// Handler returns a http.Handler that serves the metrics of the exporter.
+func (e *Exporter) Handler() http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(e)
+
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		drinkDesc,
+		targetDesc,
+		batteryDesc,
+		batteryTempDesc,
+		chargingDesc,
+		liquidLevelDesc,
+		stateDesc,
+		connectedDesc,
+		connectsDesc,
+		disconnectsDesc,
+		readErrorsDesc,
+		writeErrorsDesc,
+		pushEventsDesc,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	seen := make(map[string]bool, len(e.mugs))
+	for m, w := range e.mugs {
+		// The address is the only label, so mugs without one can't be told
+		// apart.
+		addr := m.Address().String()
+		if addr == "" || addr == "00:00:00:00:00:00" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+
+		gauge := func(desc *prometheus.Desc, v float64, labels ...string) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, append([]string{addr}, labels...)...)
+		}
+		counter := func(desc *prometheus.Desc, v uint64, labels ...string) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(v), append([]string{addr}, labels...)...)
+		}
+
+		gauge(connectedDesc, boolToFloat(w.connected))
+		counter(connectsDesc, w.connects)
+		counter(disconnectsDesc, w.disconnects)
+
+		stats := m.IOStats()
+		counter(readErrorsDesc, stats.ReadErrors)
+		counter(writeErrorsDesc, stats.WriteErrors)
+
+		for code, pc := range w.push {
+			counter(pushEventsDesc, pc.count, strconv.Itoa(int(code)), pc.name)
+		}
+
+		// The telemetry is only reported once it has been read.
+		if !w.valid {
+			continue
+		}
+
+		gauge(drinkDesc, w.info.Drink.C())
+		gauge(targetDesc, w.info.Target.C())
+		gauge(batteryDesc, w.info.Battery.PercentLeft)
+		gauge(batteryTempDesc, w.info.Battery.Temp.C())
+		gauge(chargingDesc, boolToFloat(w.info.Battery.Charging))
+		gauge(liquidLevelDesc, w.info.LiquidLevel.Percent)
+		for s := mug.Unknown; s <= mug.Hot; s++ {
+			gauge(stateDesc, boolToFloat(s == w.info.State), s.String())
+		}
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/sim"
+	"github.com/schmidtw/muggo/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, e *Exporter) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	e.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestExporter(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := sim.New(sim.WithDrink(60), sim.WithTarget(57), sim.WithBattery(80))
+	require.NoError(err)
+
+	m, err := mug.New(mug.WithTransport(s), mug.WithLogger(nil))
+	require.NoError(err)
+
+	e := New()
+	stop := e.Watch(m)
+
+	// Nothing is known until the mug connects.
+	assert.NotContains(scrape(t, e), "muggo_drink_temperature_celsius")
+
+	m.Start()
+	defer m.Stop()
+
+	const addr = `{address="00:00:5E:00:53:01"`
+	want := []string{
+		`muggo_connected` + addr + `} 1`,
+		`muggo_connects_total` + addr + `} 1`,
+		`muggo_disconnects_total` + addr + `} 0`,
+		`muggo_drink_temperature_celsius` + addr + `} 60`,
+		`muggo_target_temperature_celsius` + addr + `} 57`,
+		`muggo_battery_percent` + addr + `} 80`,
+		`muggo_battery_charging` + addr + `} 1`,
+		`muggo_liquid_level_percent` + addr + `} 100`,
+		`muggo_state` + addr + `,state="Cooling"} 1`,
+		`muggo_state` + addr + `,state="Heating"} 0`,
+		`muggo_ble_read_errors_total` + addr + `} 0`,
+		`muggo_ble_write_errors_total` + addr + `} 0`,
+	}
+	assert.Eventually(func() bool {
+		body := scrape(t, e)
+		for _, w := range want {
+			if !strings.Contains(body, w) {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	s.Pour(units.Temperature(70))
+	assert.Eventually(func() bool {
+		return strings.Contains(scrape(t, e),
+			`muggo_push_events_total`+addr+`,code="5",event="DrinkChanged"} 1`)
+	}, time.Second, 10*time.Millisecond)
+
+	s.Disconnect()
+	assert.Eventually(func() bool {
+		return strings.Contains(scrape(t, e), `muggo_disconnects_total`+addr+`} 1`)
+	}, time.Second, 10*time.Millisecond)
+
+	// Only the connection is reported while the mug is disconnected.
+	m.Stop()
+	assert.Eventually(func() bool {
+		return strings.Contains(scrape(t, e), `muggo_connected`+addr+`} 0`)
+	}, time.Second, 10*time.Millisecond)
+	body := scrape(t, e)
+	assert.Contains(body, `muggo_connected`+addr+`} 0`)
+	assert.NotContains(body, "muggo_drink_temperature_celsius")
+	assert.NotContains(body, "muggo_state")
+
+	stop()
+	assert.NotContains(scrape(t, e), "muggo_connected")
+}
+
+func TestExporter_unknownAddress(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Neither mug has connected, so neither address is known.
+	a, err := mug.New(mug.WithLogger(nil))
+	require.NoError(err)
+	b, err := mug.New(mug.WithLogger(nil))
+	require.NoError(err)
+
+	e := New()
+	defer e.Watch(a)()
+	defer e.Watch(b)()
+
+	rec := httptest.NewRecorder()
+	e.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.NotContains(rec.Body.String(), "muggo_connected")
+}
+
+func TestExporter_watchTwice(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	m, err := mug.New(mug.WithAddress("00:00:5E:00:53:01"), mug.WithLogger(nil))
+	require.NoError(err)
+
+	e := New()
+	first := e.Watch(m)
+	second := e.Watch(m)
+
+	const connected = `muggo_connected{address="00:00:5E:00:53:01"} 0`
+	assert.Contains(scrape(t, e), connected)
+
+	// The metrics stay until every watch is cancelled.
+	first()
+	first()
+	assert.Contains(scrape(t, e), connected)
+
+	second()
+	assert.NotContains(scrape(t, e), "muggo_connected")
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"fmt"
+
+	bt "tinygo.org/x/bluetooth"
+)
+
+// Model is the kind of Ember device.
+type Model int
+
+const (
+	ModelUnknown Model = iota
+	ModelMug2          // A Mug 2 of unknown size.
+	ModelTravelMug2
+	ModelMug2_10oz
+	ModelMug2_14oz
+	ModelCup
+	ModelTumbler
+)
+
+var modelStringMap = map[Model]string{
+	ModelUnknown:    "Unknown",
+	ModelMug2:       "Mug 2",
+	ModelTravelMug2: "Travel Mug 2",
+	ModelMug2_10oz:  "Mug 2 10oz",
+	ModelMug2_14oz:  "Mug 2 14oz",
+	ModelCup:        "Cup",
+	ModelTumbler:    "Tumbler",
+}
+
+func (m Model) String() string {
+	if rv, ok := modelStringMap[m]; ok {
+		return rv
+	}
+
+	return fmt.Sprintf("Unknown (%d)", m)
+}
+
+// Capabilities are the features a model supports.
+type Capabilities struct {
+	// DisplayUnits is set if the model has a display showing the temperature
+	// in the units that can be changed.
+	DisplayUnits bool
+
+	// Volume is set if the model reports the volume of the drink.
+	Volume bool
+
+	// LED is set if the model has a LED that can be changed.
+	LED bool
+
+	// TravelDisplay is set if the model has the travel mug display.
+	TravelDisplay bool
+}
+
+var modelCapabilities = map[Model]Capabilities{
+	ModelMug2:       {LED: true},
+	ModelMug2_10oz:  {LED: true},
+	ModelMug2_14oz:  {LED: true},
+	ModelCup:        {Volume: true, LED: true},
+	ModelTumbler:    {Volume: true, LED: true},
+	ModelTravelMug2: {DisplayUnits: true, TravelDisplay: true},
+}
+
+// Capabilities returns the features the model supports.  Nothing is known
+// about an unknown model, so every feature is reported as supported and
+// calls fail with ErrNotSupported if the mug lacks it.
+func (m Model) Capabilities() Capabilities {
+	if rv, ok := modelCapabilities[m]; ok {
+		return rv
+	}
+
+	return Capabilities{
+		DisplayUnits:  true,
+		Volume:        true,
+		LED:           true,
+		TravelDisplay: true,
+	}
+}
+
+// modelFromServices infers the model from the services a mug advertises.
+// The services only tell the Mug 2 and the Travel Mug 2 apart, so the sized
+// mugs, the Cup and the Tumbler are not detected yet.
+func modelFromServices(uuids []bt.UUID) Model {
+	for _, uuid := range uuids {
+		switch uuid.String() {
+		case EmberCeramicMugMainServiceUUID:
+			return ModelMug2
+		case EmberTravelMugMainServiceUUID,
+			EmberTravelMugAltMainServiceUUID,
+			EmberTravelMugPairServiceUUID:
+			return ModelTravelMug2
+		}
+	}
+
+	return ModelUnknown
+}
+
+// Model returns the model of the mug.  The model is only known once the mug
+// has been connected to.
+func (m *Mug) Model() Model {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	return m.model()
+}
+
+// model returns the model of the mug.  The lock must be held.
+func (m *Mug) model() Model {
+	return modelFromServices(m.services)
+}
+
+// Capabilities returns the features the mug supports.
+func (m *Mug) Capabilities() Capabilities {
+	return m.Model().Capabilities()
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	bt "tinygo.org/x/bluetooth"
+)
+
+func Test_modelFromServices(t *testing.T) {
+	tests := []struct {
+		description string
+		services    []bt.UUID
+		want        Model
+		wantCaps    Capabilities
+	}{
+		{
+			description: "nothing known",
+			want:        ModelUnknown,
+			wantCaps:    Capabilities{DisplayUnits: true, Volume: true, LED: true, TravelDisplay: true},
+		}, {
+			description: "ceramic service",
+			services:    []bt.UUID{mustParseUUID(EmberCeramicMugMainServiceUUID)},
+			want:        ModelMug2,
+			wantCaps:    Capabilities{LED: true},
+		}, {
+			description: "travel service",
+			services:    []bt.UUID{mustParseUUID(EmberTravelMugMainServiceUUID)},
+			want:        ModelTravelMug2,
+			wantCaps:    Capabilities{DisplayUnits: true, TravelDisplay: true},
+		}, {
+			description: "travel pair service",
+			services:    []bt.UUID{mustParseUUID(EmberTravelMugPairServiceUUID)},
+			want:        ModelTravelMug2,
+			wantCaps:    Capabilities{DisplayUnits: true, TravelDisplay: true},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got := modelFromServices(tc.services)
+			assert.Equal(tc.want, got)
+			assert.Equal(tc.wantCaps, got.Capabilities())
+		})
+	}
+}
+
+func TestModel_Capabilities(t *testing.T) {
+	assert := assert.New(t)
+
+	// Cups and tumblers report the volume, the travel mug does not.
+	assert.True(ModelCup.Capabilities().Volume)
+	assert.True(ModelTumbler.Capabilities().Volume)
+	assert.False(ModelTravelMug2.Capabilities().Volume)
+
+	// Only the travel mug has a display showing the units.
+	assert.False(ModelMug2.Capabilities().DisplayUnits)
+	assert.True(ModelTravelMug2.Capabilities().DisplayUnits)
+}
+
+func TestModel_String(t *testing.T) {
+	assert.Equal(t, "Mug 2 10oz", ModelMug2_10oz.String())
+	assert.Equal(t, "Unknown (99)", Model(99).String())
+}
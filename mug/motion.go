@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Acceleration is the acceleration the mug measures in g along each axis.
+// When the mug is sitting upright and still, Z is 1.
+type Acceleration struct {
+	X, Y, Z float64
+}
+
+// Magnitude returns the magnitude of the acceleration in g.
+func (a Acceleration) Magnitude() float64 {
+	return math.Sqrt(a.X*a.X + a.Y*a.Y + a.Z*a.Z)
+}
+
+// Tilt returns how far the mug is tilted from upright in degrees.
+func (a Acceleration) Tilt() float64 {
+	mag := a.Magnitude()
+	if mag == 0 {
+		return 0
+	}
+	return math.Acos(math.Max(-1, math.Min(1, a.Z/mag))) * 180 / math.Pi
+}
+
+// Acceleration returns the current acceleration of the mug.
+//
+// Reading the acceleration is experimental.  The format of the
+// characteristic is not documented by Ember, has not been checked against a
+// capture from real hardware, and is not modeled by the simulator.
+func (m *Mug) Acceleration() (Acceleration, error) {
+	return m.AccelerationContext(context.Background())
+}
+
+// AccelerationContext is like Acceleration but uses the context for the
+// request.
+func (m *Mug) AccelerationContext(ctx context.Context) (Acceleration, error) {
+	data, _, err := m.io(ctx, m, mugApi_ACCERATION, 6)
+	if err != nil {
+		return Acceleration{}, err
+	}
+
+	a := accelerationFromData(data)
+
+	m.m.Lock()
+	motions := m.motion.update(a)
+	m.m.Unlock()
+
+	m.notifyMotion(a, motions...)
+
+	return a, nil
+}
+
+// accelerationFromData decodes the acceleration.  The layout is assumed to
+// be each axis as a little endian signed 16 bit value in thousandths of a g;
+// it is unverified.
+func accelerationFromData(data []byte) Acceleration {
+	if len(data) < 6 {
+		return Acceleration{}
+	}
+
+	axis := func(b []byte) float64 {
+		return float64(int16(binary.LittleEndian.Uint16(b))) / 1000
+	}
+
+	return Acceleration{
+		X: axis(data[0:2]),
+		Y: axis(data[2:4]),
+		Z: axis(data[4:6]),
+	}
+}
+
+// AccelerationTTL sets the TTL for the acceleration of the mug.  The
+// acceleration is only polled while there are motion listeners.
+func AccelerationTTL(ttl time.Duration) Option {
+	return OptionFunc(func(mug *Mug) error {
+		mug.apis[mugApi_ACCERATION].ttl = ttl
+		return nil
+	})
+}
+
+// Motion is a higher level movement of the mug.
+type Motion int
+
+const (
+	PickedUp Motion = iota + 1
+	SetDown
+	Tilted // Usually someone taking a sip.
+	KnockedOver
+)
+
+var motionStringMap = map[Motion]string{
+	PickedUp:    "PickedUp",
+	SetDown:     "SetDown",
+	Tilted:      "Tilted",
+	KnockedOver: "KnockedOver",
+}
+
+func (m Motion) String() string {
+	if rv, ok := motionStringMap[m]; ok {
+		return rv
+	}
+
+	return fmt.Sprintf("Unknown (%d)", m)
+}
+
+// MotionEvent is sent to the motion listeners each time the mug moves.
+type MotionEvent struct {
+	Motion       Motion
+	Acceleration Acceleration
+}
+
+type MotionListener interface {
+	OnMotion(MotionEvent)
+}
+
+// MotionListenerFunc is a convenience type for implementing the
+// MotionListener interface with a function.
+type MotionListenerFunc func(MotionEvent)
+
+func (f MotionListenerFunc) OnMotion(e MotionEvent) {
+	f(e)
+}
+
+// AddMotionListener adds a listener that is called when the mug is picked up,
+// set down, tilted or knocked over.  Motion events are experimental, since
+// they are built on the unverified acceleration decoding and thresholds that
+// have not been tuned on real hardware.  The mug acceleration is polled while
+// there is at least one motion listener.  The events are queued for the
+// listener, see ListenerOption.  The oldest events are dropped if the
+// listener falls behind, unless another policy is chosen.
+func (m *Mug) AddMotionListener(l MotionListener, opts ...ListenerOption) CancelFunc {
+	return addListener(&m.motionListeners, newEventConfig(opts), &m.listenerCounters, nil, l.OnMotion)
+}
+
+func (m *Mug) hasMotionListeners() bool {
+	var found bool
+	m.motionListeners.Visit(func(*queue[MotionEvent]) {
+		found = true
+	})
+	return found
+}
+
+func (m *Mug) notifyMotion(a Acceleration, motions ...Motion) {
+	for _, motion := range motions {
+		e := MotionEvent{
+			Motion:       motion,
+			Acceleration: a,
+		}
+		notify(&m.motionListeners, e)
+	}
+}
+
+// The positions of the mug the motion detector tracks.
+type position int
+
+const (
+	posUnknown position = iota
+	posResting
+	posMoving
+	posTilted
+	posOver
+)
+
+// The thresholds are guesses that have not been tuned on real hardware.
+const (
+	// How far from 1g the magnitude may be and still be considered still.
+	stillDelta = 0.15
+
+	// Tilt angles in degrees.
+	uprightTilt = 10
+	sipTilt     = 35
+	overTilt    = 70
+)
+
+// motionDetector turns acceleration samples and coaster changes into motions.
+type motionDetector struct {
+	pos  position
+	last Acceleration
+}
+
+func classify(a Acceleration) position {
+	still := math.Abs(a.Magnitude()-1) < stillDelta
+	tilt := a.Tilt()
+
+	switch {
+	case still && tilt >= overTilt:
+		return posOver
+	case tilt >= sipTilt:
+		return posTilted
+	case !still || tilt >= uprightTilt:
+		return posMoving
+	}
+
+	return posResting
+}
+
+// update moves the detector to the position of the sample and returns the
+// resulting motions.
+func (d *motionDetector) update(a Acceleration) []Motion {
+	d.last = a
+	return d.moveTo(classify(a))
+}
+
+// coaster updates the detector when the mug is placed on or lifted off the
+// charging coaster.
+func (d *motionDetector) coaster(on bool) []Motion {
+	if on {
+		return d.moveTo(posResting)
+	}
+
+	if d.pos == posResting {
+		return d.moveTo(posMoving)
+	}
+
+	return nil
+}
+
+func (d *motionDetector) moveTo(pos position) []Motion {
+	prev := d.pos
+	d.pos = pos
+
+	if prev == pos || prev == posUnknown {
+		return nil
+	}
+
+	switch pos {
+	case posResting:
+		return []Motion{SetDown}
+	case posOver:
+		return []Motion{KnockedOver}
+	case posMoving:
+		if prev == posResting || prev == posOver {
+			return []Motion{PickedUp}
+		}
+	case posTilted:
+		if prev == posResting || prev == posOver {
+			return []Motion{PickedUp, Tilted}
+		}
+		return []Motion{Tilted}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	upright  = Acceleration{Z: 1}
+	shaking  = Acceleration{X: 0.3, Z: 1.2}
+	sipping  = Acceleration{X: 0.7, Z: 0.7}
+	onItSide = Acceleration{X: 1}
+)
+
+func Test_accelerationFromData(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(Acceleration{X: 0.001, Y: -0.001, Z: 1}, accelerationFromData([]byte{0x01, 0x00, 0xff, 0xff, 0xe8, 0x03}))
+	assert.Equal(Acceleration{}, accelerationFromData([]byte{0x01}))
+	assert.InDelta(45.0, sipping.Tilt(), 0.001)
+	assert.InDelta(0.0, upright.Tilt(), 0.001)
+}
+
+func Test_motionDetector(t *testing.T) {
+	type step struct {
+		sample  *Acceleration
+		coaster *bool
+		want    []Motion
+	}
+
+	on, off := true, false
+
+	tests := []struct {
+		description string
+		steps       []step
+	}{
+		{
+			description: "the first sample sets the position",
+			steps: []step{
+				{sample: &sipping},
+				{sample: &sipping},
+			},
+		}, {
+			description: "a sip",
+			steps: []step{
+				{sample: &upright},
+				{sample: &shaking, want: []Motion{PickedUp}},
+				{sample: &sipping, want: []Motion{Tilted}},
+				{sample: &shaking},
+				{sample: &sipping, want: []Motion{Tilted}},
+				{sample: &upright, want: []Motion{SetDown}},
+			},
+		}, {
+			description: "picked up straight into a sip",
+			steps: []step{
+				{sample: &upright},
+				{sample: &sipping, want: []Motion{PickedUp, Tilted}},
+			},
+		}, {
+			description: "knocked over and picked up",
+			steps: []step{
+				{sample: &upright},
+				{sample: &onItSide, want: []Motion{KnockedOver}},
+				{sample: &onItSide},
+				{sample: &shaking, want: []Motion{PickedUp}},
+			},
+		}, {
+			description: "the coaster",
+			steps: []step{
+				{sample: &upright},
+				{coaster: &off, want: []Motion{PickedUp}},
+				{sample: &shaking},
+				{coaster: &on, want: []Motion{SetDown}},
+				{coaster: &on},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var d motionDetector
+			for i, s := range tc.steps {
+				var got []Motion
+				if s.sample != nil {
+					got = d.update(*s.sample)
+				} else {
+					got = d.coaster(*s.coaster)
+				}
+				assert.Equal(s.want, got, "step %d", i)
+			}
+		})
+	}
+}
@@ -4,10 +4,13 @@
 package mug
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/schmidtw/muggo/mug/event"
@@ -54,12 +57,31 @@ var (
 	ErrNotSupported = errors.New("not supported by mug")
 	ErrNotConnected = errors.New("not connected to mug")
 	ErrInvalidInput = errors.New("invalid input")
+	ErrTimeout      = errors.New("timed out talking to mug")
+
+	// ErrConnectionLost is the reason given when the mug drops the
+	// connection.
+	ErrConnectionLost = errors.New("connection to mug lost")
+
+	// ErrMaxAttempts is the reason given when the mug gives up connecting
+	// after Backoff.MaxAttempts failed attempts in a row.
+	ErrMaxAttempts = errors.New("too many failed connection attempts")
 )
 
+// defaultInitialReadTimeout bounds the reads made when the mug connects if
+// there is no IOTimeout.
+const defaultInitialReadTimeout = 10 * time.Second
+
 var (
 	Defaults = []Option{
 		WithAdapter(bt.DefaultAdapter),
-		RetryInterval(5 * time.Second),
+		WithBackoff(Backoff{
+			Initial:    time.Second,
+			Max:        time.Minute,
+			Multiplier: 2,
+			Jitter:     0.2,
+		}),
+		IOTimeout(10 * time.Second),
 		WithServiceUUIDs(
 			EmberCeramicMugMainServiceUUID,
 			EmberTravelMugMainServiceUUID,
@@ -68,10 +90,12 @@ var (
 		),
 		// Set the timeouts so they don't all happen at once if possible.
 		// Fast moving data
+		AccelerationTTL(500 * time.Millisecond),
 		DrinkTTL(8 * time.Second),
 		EmptyTTL(9 * time.Second),
 		StateTTL(10 * time.Second),
 		BatteryTTL(15 * time.Second),
+		VolumeTTL(16 * time.Second),
 
 		// Slow moving data
 		NameTTL(24 * time.Hour),
@@ -86,24 +110,64 @@ type Mug struct {
 	m  sync.Mutex
 	wg sync.WaitGroup
 
-	adapter  *bt.Adapter
-	interval time.Duration
+	adapter   *bt.Adapter
+	transport Transport
+	base      Transport // the transport before it is wrapped
+	wrappers  []func(Transport) Transport
+	backoff   Backoff
+	timeout   time.Duration
+
+	// ioSlot serializes the operations with the mug.
+	ioSlot chan struct{}
+
+	mugListeners              eventor.Eventor[*queue[MugInfo]]
+	mugChangeListeners        eventor.Eventor[*queue[MugChange]]
+	changeConnectionListeners eventor.Eventor[*queue[event.ConnectionChange]]
+	motionListeners           eventor.Eventor[*queue[MotionEvent]]
+	stateListeners            eventor.Eventor[*queue[event.StateChange]]
+	pushListeners             eventor.Eventor[*queue[PushEvent]]
+	listenerCounters          listenerCounters
+	ioCounters                ioCounters
 
-	mugListeners              eventor.Eventor[MugListener]
-	changeConnectionListeners eventor.Eventor[event.ConnectionChangeListener]
+	motion motionDetector
+
+	// last is the MugInfo from the last dispatch, used to find the changes.
+	dispatchM sync.Mutex
+	last      MugInfo
 
 	address      bt.Address
 	serviceUUIDs []bt.UUID
 
+	// services are the services of the connected mug.
+	services []bt.UUID
+
+	// cancelOptions is set when a listener option was given a cancel
+	// function to fill in, which a Fleet can't honor since it applies the
+	// options to every mug.
+	cancelOptions bool
+
+	// managed is set when the mug is owned by a Fleet, which does the
+	// scanning and connecting on the mug's behalf.
+	managed   bool
+	connected bool
+	state     event.ConnectionState
+
+	keys KeyStore
+
+	// clockLocation is set when the mug's clock is synced on connect.
+	clockLocation *time.Location
+
 	connShutdown context.CancelFunc
 
 	shutdown context.CancelFunc
+	runCtx   context.Context
 	now      func() time.Time
+	logger   *slog.Logger
 
 	apis map[int]*cached
 
 	// This makes testing easier because we can mock the device easily.
-	io func(m *Mug, api int, length int, write ...[]byte) ([]byte, bool, error)
+	io func(ctx context.Context, m *Mug, api int, length int, write ...[]byte) ([]byte, bool, error)
 }
 
 type Option interface {
@@ -118,9 +182,12 @@ func (f OptionFunc) apply(mug *Mug) error {
 
 func New(opts ...Option) (*Mug, error) {
 	mug := Mug{
-		now:  time.Now,
-		apis: make(map[int]*cached),
-		io:   lockedIO,
+		now:    time.Now,
+		logger: slog.Default(),
+		apis:   make(map[int]*cached),
+		io:     lockedIO,
+		keys:   &MemoryKeyStore{},
+		ioSlot: make(chan struct{}, 1),
 	}
 
 	for i := 0; i < mugApi_LAST; i++ {
@@ -138,6 +205,15 @@ func New(opts ...Option) (*Mug, error) {
 		}
 	}
 
+	if mug.transport == nil {
+		mug.transport = newBLETransport(mug.logger, mug.adapter, mug.address, mug.serviceUUIDs)
+	}
+	mug.base = mug.transport
+	mug.transport = mug.wrap(mug.transport)
+
+	// The changes are relative to what is known before anything is read.
+	mug.last = mug.All()
+
 	return &mug, nil
 }
 
@@ -145,12 +221,13 @@ func (m *Mug) Start() {
 	m.m.Lock()
 	defer m.m.Unlock()
 
-	if m.shutdown != nil {
+	if m.shutdown != nil || m.managed {
 		return
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	m.shutdown = cancel
+	m.runCtx = ctx
 	go m.run(ctx)
 }
 
@@ -165,199 +242,193 @@ func (m *Mug) Stop() {
 	}
 }
 
-func (m *Mug) AddConnectionChangeListener(listener event.ConnectionChangeListener) {
-	m.changeConnectionListeners.Add(listener)
+// AddConnectionChangeListener adds a listener that is called each time the
+// mug connects or disconnects.  The events are queued for the listener, see
+// ListenerOption.  The oldest events are dropped if the listener falls
+// behind, unless another policy is chosen.
+func (m *Mug) AddConnectionChangeListener(listener event.ConnectionChangeListener, opts ...ListenerOption) CancelFunc {
+	return addListener(&m.changeConnectionListeners, newEventConfig(opts),
+		&m.listenerCounters, nil, listener.OnConnectionChange)
 }
 
 func (m *Mug) run(ctx context.Context) {
 	m.wg.Add(1)
 	defer m.wg.Done()
 
+	var attempt int
 	for {
-		err := m.adapter.Enable()
+		m.setState(event.Scanning, nil)
+		conn, err := m.transport.Connect(withStateReporter(ctx, m.setState))
 		if err != nil {
-			fmt.Println(err)
-			time.Sleep(time.Second)
-			continue
-		}
-		break
-	}
-
-	disconnected := make(chan struct{}, 1)
-
-	m.adapter.SetConnectHandler(
-		func(address bt.Address, connected bool) {
-			m.connectHandler(disconnected, address, connected)
-		})
-
-	var connected bool
+			if ctx.Err() != nil {
+				m.setState(event.Disconnected, nil)
+				return
+			}
 
-	for {
-		if !connected {
-			result, err := m.scan(ctx)
-			if err == nil {
-				err = m.connect(result)
-				if err == nil {
-					connected = true
-				}
+			attempt++
+			if m.backoff.MaxAttempts > 0 && attempt >= m.backoff.MaxAttempts {
+				m.giveUp(ctx, attempt, err)
+				return
 			}
 
-			if err != nil {
-				fmt.Println(err)
-				time.Sleep(m.interval)
-				continue
+			delay := m.backoff.Delay(attempt)
+			m.logger.Warn("unable to connect to mug", errAttr(err),
+				slog.Int("attempt", attempt), slog.Duration("retry", delay))
+			m.stateChange(event.StateChange{
+				State:   event.Backoff,
+				Err:     err,
+				Attempt: attempt,
+				Delay:   delay,
+			})
+
+			select {
+			case <-ctx.Done():
+				m.setState(event.Disconnected, nil)
+				return
+			case <-time.After(delay):
 			}
+			continue
 		}
 
+		attempt = 0
+		m.connect(conn)
+
 		select {
 		case <-ctx.Done():
+			m.disconnect(nil)
 			return
 
-		case <-disconnected:
-			fmt.Println("disconnected in loop")
-			connected = false
-			m.disconnect()
+		case <-conn.Disconnected:
+			m.disconnect(ErrConnectionLost)
 		}
 	}
 }
 
-func (m *Mug) connectHandler(notify chan struct{}, address bt.Address, connected bool) {
-	m.m.Lock()
-	want := m.address
-	m.m.Unlock()
+// giveUp stops trying to connect after too many failed attempts.  The mug can
+// be started again with Start().
+func (m *Mug) giveUp(ctx context.Context, attempt int, err error) {
+	err = errors.Join(ErrMaxAttempts, err)
+	m.logger.Error("giving up connecting to mug", errAttr(err), slog.Int("attempt", attempt))
 
-	if address != want {
-		return
+	m.m.Lock()
+	if m.runCtx == ctx && m.shutdown != nil {
+		m.shutdown()
+		m.shutdown = nil
 	}
+	m.m.Unlock()
 
-	if !connected {
-		notify <- struct{}{}
-	}
+	m.stateChange(event.StateChange{
+		State:   event.Disconnected,
+		Err:     err,
+		Attempt: attempt,
+	})
 }
 
-func (m *Mug) scan(ctx context.Context) (*bt.ScanResult, error) {
-	type scanResult struct {
-		result bt.ScanResult
-		err    error
-	}
-
-	ch := make(chan scanResult, 1)
-
-	go func() {
-		err := m.adapter.Scan(func(adapter *bt.Adapter, r bt.ScanResult) {
-			if r.Address == m.address {
-				_ = adapter.StopScan()
-				ch <- scanResult{result: r}
-				return
-			}
-
-			for _, service := range m.serviceUUIDs {
-				if r.HasServiceUUID(service) {
-					_ = adapter.StopScan()
-					ch <- scanResult{result: r}
-					return
-				}
-			}
-		})
-		if err != nil {
-			ch <- scanResult{err: err}
+// enableAdapter keeps trying to enable the adapter until it succeeds or the
+// context is done.
+func enableAdapter(ctx context.Context, logger *slog.Logger, adapter *bt.Adapter) error {
+	for {
+		err := adapter.Enable()
+		if err == nil {
+			return nil
 		}
-	}()
+		logger.Warn("unable to enable the adapter", errAttr(err))
+		reportState(ctx, event.AdapterDisabled, err)
 
-	select {
-	case <-ctx.Done():
-		m.m.Lock()
-		m.address = bt.Address{}
-		if m.connShutdown != nil {
-			m.connShutdown()
-		}
-		m.m.Unlock()
-		return nil, ctx.Err()
-	case result := <-ch:
-		if result.err != nil {
-			return nil, result.err
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
 		}
-		return &result.result, nil
 	}
 }
 
-func (m *Mug) connect(result *bt.ScanResult) error {
-	fmt.Println("found one, connecting")
-	address := result.Address
-	device, err := m.adapter.Connect(address, bt.ConnectionParams{})
-	if err != nil {
-		return err
+func (m *Mug) connect(conn *Connection) {
+	m.m.Lock()
+	m.address = conn.Address
+	m.services = conn.ServiceUUIDs
+	ids := make([]int, 0, len(conn.Characteristics))
+	for _, char := range conn.Characteristics {
+		id := uuidToApiId(char.UUID())
+		if _, ok := m.apis[id]; !ok {
+			m.apis[id] = &cached{}
+		}
+		m.apis[id].characteristic = char
+		ids = append(ids, id)
 	}
+	m.m.Unlock()
 
-	services, err := device.DiscoverServices(nil)
-	if err != nil {
-		return err
+	// If we are connected, we want to read the mug data without delay.  The
+	// reads share one deadline so a stuck mug can't hold up the connection
+	// for long, and they go through the normal io path so the mug lock is
+	// not held while talking to the mug.
+	timeout := m.timeout
+	if timeout <= 0 {
+		timeout = defaultInitialReadTimeout
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	for _, id := range ids {
+		data, _, err := m.io(ctx, m, id, 0)
+		if err != nil {
+			m.logger.Debug("initial read failed", addressAttr(conn.Address), idAttr(id), errAttr(err))
+		} else {
+			m.logger.Debug("initial read", addressAttr(conn.Address), idAttr(id), dataAttr(data))
+		}
+	}
+	cancel()
 
 	m.m.Lock()
-	m.address = address
-	wait := sync.WaitGroup{}
-	for _, service := range services {
-		if !m.isWantedService(service.UUID()) {
-			continue
-		}
+	connCtx, cancel := context.WithCancel(context.Background())
+	m.connShutdown = cancel
+	m.connected = true
+	err := m.startNotifications(connCtx)
+	loc := m.clockLocation
+	m.m.Unlock()
 
-		chars, err := service.DiscoverCharacteristics(nil)
-		if err != nil {
-			return err
-		}
+	if err := m.restoreKey(context.Background()); err != nil {
+		m.logger.Warn("unable to restore the pairing key", addressAttr(conn.Address), errAttr(err))
+	}
 
-		for i := range chars {
-			char := &chars[i]
-			id := uuidToApiId(char.UUID())
-			if _, ok := m.apis[id]; !ok {
-				m.apis[id] = &cached{}
-			}
-			m.apis[id].characteristic = char
-
-			// If we are connected, we want to read the mug data without delay.
-			wait.Add(1)
-			go func() {
-				data, err := m.apis[id].read(m.now())
-				if err != nil {
-					fmt.Printf("id: %d - err: %v\n", id, err)
-				} else {
-					fmt.Printf("id: %d - data: %x\n", id, data)
-				}
-				wait.Done()
-			}()
+	if loc != nil {
+		if err := m.SetClock(m.now(), loc); err != nil {
+			m.logger.Warn("unable to set the mug clock", addressAttr(conn.Address), errAttr(err))
 		}
 	}
 
-	wait.Wait()
+	if err != nil {
+		m.logger.Warn("unable to enable notifications", addressAttr(conn.Address), errAttr(err))
+	}
+	m.logger.Info("mug connected", addressAttr(conn.Address),
+		slog.Int("characteristics", len(conn.Characteristics)))
 
-	connCtx, cancel := context.WithCancel(context.Background())
-	m.connShutdown = cancel
-	err = m.startNotifications(connCtx)
-	m.m.Unlock()
+	m.setState(event.Connected, nil)
 	m.notifyConnectionChange(true)
 	m.dispatch()
-
-	return err
 }
 
-func (m *Mug) disconnect() {
+// disconnect cleans up after the connection to the mug ends.  The error is
+// the reason the connection ended, if any.
+func (m *Mug) disconnect(err error) {
 	m.m.Lock()
 	for k := range m.apis {
 		m.apis[k].characteristic = nil
 	}
+	if m.connShutdown != nil {
+		m.connShutdown()
+		m.connShutdown = nil
+	}
+	m.connected = false
+	address := m.address
 	m.m.Unlock()
 
-	m.notifyConnectionChange(false)
-}
-
-func (m *Mug) isWantedService(uuid bt.UUID) bool {
-	for _, service := range m.serviceUUIDs {
-		if uuid.String() == service.String() {
-			return true
-		}
+	if err != nil {
+		m.logger.Info("mug disconnected", addressAttr(address), errAttr(err))
+	} else {
+		m.logger.Info("mug disconnected", addressAttr(address))
 	}
-	return false
+
+	m.setState(event.Disconnected, err)
+	m.notifyConnectionChange(false)
 }
 
 func (m *Mug) notifyConnectionChange(connected bool) {
@@ -369,53 +440,96 @@ func (m *Mug) notifyConnectionChange(connected bool) {
 		Address:   address,
 		Connected: connected,
 	}
-	m.changeConnectionListeners.Visit(func(listener event.ConnectionChangeListener) {
-		listener.OnConnectionChange(cc)
-	})
+	notify(&m.changeConnectionListeners, cc)
 }
 
-func uuidToApiId(uuid bt.UUID) int {
-	id := uuid.Bytes()
-	return (0xff&int(id[13]))<<8 + (0xff & int(id[12]))
+// Address returns the address of the mug.  The address is empty until the
+// mug has been found unless it was provided via WithAddress().
+func (m *Mug) Address() bt.Address {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	return m.address
 }
 
-func lockedIO(m *Mug, api int, length int, write ...[]byte) ([]byte, bool, error) {
+// IsConnected returns if the mug is currently connected.
+func (m *Mug) IsConnected() bool {
 	m.m.Lock()
 	defer m.m.Unlock()
 
-	prev := m.apis[api].data
+	return m.connected
+}
 
+// uuidToApiId returns the characteristic id from the 3rd and 4th bytes of
+// the UUID, the xxxx in fc54xxxx-236c-4c94-8fa9-944a3e5353fa.  The bytes are
+// read big endian since the bluetooth module no longer offers the little
+// endian UUID.Bytes().
+func uuidToApiId(uuid bt.UUID) int {
+	id := uuid.BytesBigEndian()
+	return (0xff&int(id[2]))<<8 + (0xff & int(id[3]))
+}
+
+func lockedIO(ctx context.Context, m *Mug, api int, length int, write ...[]byte) ([]byte, bool, error) {
+	if m.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+	}
+
+	m.m.Lock()
 	impl, ok := m.apis[api]
 	if !ok || impl == nil {
+		m.m.Unlock()
 		return nil, false, ErrNotSupported
 	}
 
-	if len(write) > 0 {
-		_, err := impl.write(write[0])
+	char := impl.characteristic
+	address := m.address
+	prev := impl.data
+	now := m.now()
+	useCache := len(write) == 0 && impl.returnCached(now)
+	connected := m.connected
+	m.m.Unlock()
+
+	if char == nil {
+		// A connected mug without the characteristic doesn't support it.
+		if connected {
+			return nil, false, ErrNotSupported
+		}
+		return nil, false, ErrNotConnected
+	}
+
+	rv := prev
+	if !useCache {
+		// The mug lock is not held while talking to the mug so a slow
+		// read does not block everyone else.
+		data, err := m.exchange(ctx, char, write...)
 		if err != nil {
+			m.logger.Debug("characteristic io failed", addressAttr(address), idAttr(api), errAttr(err))
+			if len(write) > 0 {
+				m.m.Lock()
+				impl.expire()
+				m.m.Unlock()
+			}
 			return nil, false, err
 		}
-	}
 
-	rv, err := impl.read(m.now())
-	if err != nil {
-		return nil, false, err
-	}
+		m.m.Lock()
+		impl.data = data
+		impl.fetched = now
+		m.m.Unlock()
+		rv = data
 
-	changed := false
-	if len(rv) != len(prev) {
-		changed = true
-	} else {
-		for i := 0; i < len(rv); i++ {
-			if rv[i] != prev[i] {
-				changed = true
-				break
-			}
+		if len(write) > 0 {
+			m.logger.Debug("characteristic write", addressAttr(address), idAttr(api), dataAttr(write[0]))
 		}
+		m.logger.Debug("characteristic read", addressAttr(address), idAttr(api), dataAttr(data))
 	}
 
+	changed := !bytes.Equal(rv, prev)
+
 	if length < 1 {
-		return rv, changed, err
+		return rv, changed, nil
 	}
 
 	if len(rv) != length {
@@ -424,3 +538,56 @@ func lockedIO(m *Mug, api int, length int, write ...[]byte) ([]byte, bool, error
 
 	return rv, changed, nil
 }
+
+// exchange writes to and then reads from the characteristic, giving up when
+// the context is done.  Bluetooth operations can't be cancelled, so a stuck
+// operation holds up the operations behind it until it finishes.
+func (m *Mug) exchange(ctx context.Context, char Characteristic, write ...[]byte) ([]byte, error) {
+	select {
+	case m.ioSlot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctxErr(ctx)
+	}
+
+	type result struct {
+		data []byte
+		err  error
+	}
+
+	// The error is counted against the write until it succeeds.
+	var wrote atomic.Bool
+
+	done := make(chan result, 1)
+	go func() {
+		defer func() { <-m.ioSlot }()
+
+		var r result
+		if len(write) > 0 {
+			_, r.err = char.WriteWithoutResponse(write[0])
+			wrote.Store(r.err == nil)
+		}
+		if r.err == nil {
+			r.data, r.err = readCharacteristic(char)
+		}
+		done <- r
+	}()
+
+	select {
+	case r := <-done:
+		m.ioCounters.countExchange(len(write) > 0, wrote.Load(), r.err)
+		return r.data, r.err
+	case <-ctx.Done():
+		err := ctxErr(ctx)
+		m.ioCounters.countExchange(len(write) > 0, wrote.Load(), err)
+		return nil, err
+	}
+}
+
+// ctxErr converts the error of a done context into the error returned to
+// the caller.
+func ctxErr(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrTimeout, ctx.Err())
+	}
+	return ctx.Err()
+}
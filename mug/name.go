@@ -4,18 +4,24 @@
 package mug
 
 import (
+	"context"
 	"time"
 )
 
 // Name returns the name of the mug as a string.  If the optional name
 // parameter is provided, it will be used to set the name of the mug first.
 func (m *Mug) Name(name ...string) (string, error) {
+	return m.NameContext(context.Background(), name...)
+}
+
+// NameContext is like Name but uses the context for the request.
+func (m *Mug) NameContext(ctx context.Context, name ...string) (string, error) {
 	var write [][]byte
 	if len(name) > 0 {
 		write = [][]byte{[]byte(name[0])}
 	}
 
-	data, _, err := m.io(m, mugApi_NAME, 0, write...)
+	data, _, err := m.io(ctx, m, mugApi_NAME, 0, write...)
 
 	return nameFromData(data), err
 }
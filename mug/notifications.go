@@ -5,7 +5,7 @@ package mug
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 	"time"
 )
 
@@ -21,41 +21,51 @@ const (
 )
 
 func (m *Mug) startNotifications(ctx context.Context) error {
-	err := m.apis[mugApi_PUSH_EVENT].characteristic.EnableNotifications(
-		func(buf []byte) {
+	var err error
 
-			switch buf[0] {
-			case NOTIFY_BATTERY:
-				go m.refreshbattery()
+	// Not every transport is able to push events, so fall back to polling.
+	if push := m.apis[mugApi_PUSH_EVENT].characteristic; push != nil {
+		err = push.EnableNotifications(m.onPushEvent)
+	}
 
-			case NOTIFY_CHARGHING:
-				go m.charging()
+	go m.handleMissingNotifications(ctx)
 
-			case NOTIFY_DISCHARGING:
-				go m.discharging()
+	return err
+}
 
-			case NOTIFY_TARGET_CHANGED:
-				fmt.Println("notify: target changed")
-				go m.targetChanged()
+func (m *Mug) onPushEvent(buf []byte) {
+	if len(buf) == 0 {
+		return
+	}
 
-			case NOTIFY_DRINK_CHANGED:
-				go m.drinkChanged()
+	e := pushEventFromData(buf)
+	m.logger.Debug("push event", addressAttr(m.Address()),
+		slog.String("type", e.Type.String()), dataAttr(buf))
 
-			case NOTIFY_LIQUID_LEVEL_CHANGED:
-				go m.emptyChanged()
+	m.notifyPushEvent(e)
 
-			case NOTIFY_STATE_CHANGED:
-				fmt.Println("notify: state changed")
-				go m.stateChanged()
-			default:
-				fmt.Println("unknown push event:", buf)
-			}
-		},
-	)
+	switch buf[0] {
+	case NOTIFY_BATTERY:
+		go m.refreshbattery()
 
-	go m.handleMissingNotifications(ctx)
+	case NOTIFY_CHARGHING:
+		go m.charging()
 
-	return err
+	case NOTIFY_DISCHARGING:
+		go m.discharging()
+
+	case NOTIFY_TARGET_CHANGED:
+		go m.targetChanged()
+
+	case NOTIFY_DRINK_CHANGED:
+		go m.drinkChanged()
+
+	case NOTIFY_LIQUID_LEVEL_CHANGED:
+		go m.emptyChanged()
+
+	case NOTIFY_STATE_CHANGED:
+		go m.stateChanged()
+	}
 }
 
 func (m *Mug) handleMissingNotifications(ctx context.Context) {
@@ -69,9 +79,13 @@ func (m *Mug) handleMissingNotifications(ctx context.Context) {
 			_, _ = m.Drink()
 			_, _ = m.Target()
 			_, _ = m.BatteryInfo()
-			_, _ = m.IsEmpty()
+			_, _ = m.LiquidLevel()
 			_, _ = m.State()
 			_, _ = m.Units()
+			_, _ = m.Volume()
+			if m.hasMotionListeners() {
+				_, _ = m.Acceleration()
+			}
 		}
 	}
 }
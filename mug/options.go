@@ -4,6 +4,8 @@
 package mug
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/schmidtw/muggo/mug/event"
@@ -17,12 +19,16 @@ func WithAdapter(a *bt.Adapter) Option {
 	})
 }
 
+// WithAddress sets the address of the mug to connect to, as printed by
+// Advertisement.Address.String().
 func WithAddress(mac string) Option {
 	return OptionFunc(func(mug *Mug) error {
-		var macAddr bt.MACAddress
-		macAddr.Set(mac)
+		m, err := bt.ParseMAC(strings.ToUpper(strings.TrimSpace(mac)))
+		if err != nil {
+			return errors.Join(ErrInvalidInput, err)
+		}
 		mug.address = bt.Address{
-			MACAddress: macAddr,
+			MACAddress: bt.MACAddress{MAC: m},
 		}
 		return nil
 	})
@@ -47,17 +53,43 @@ type CancelEventListenerFunc func()
 
 func WithChangeConnectionListener(listener event.ConnectionChangeListener, cancel ...*CancelEventListenerFunc) Option {
 	return OptionFunc(func(mug *Mug) error {
-		cf := mug.changeConnectionListeners.Add(listener)
+		cf := mug.AddConnectionChangeListener(listener)
+		if len(cancel) > 0 {
+			*cancel[0] = CancelEventListenerFunc(cf)
+			mug.cancelOptions = true
+		}
+		return nil
+	})
+}
+
+// WithStateChangeListener adds a listener that is called each time the
+// connection state of the mug changes.
+func WithStateChangeListener(listener event.StateChangeListener, cancel ...*CancelEventListenerFunc) Option {
+	return OptionFunc(func(mug *Mug) error {
+		cf := mug.AddStateChangeListener(listener)
 		if len(cancel) > 0 {
 			*cancel[0] = CancelEventListenerFunc(cf)
+			mug.cancelOptions = true
 		}
 		return nil
 	})
 }
 
+// RetryInterval sets a fixed interval between failed connection attempts.
+// It replaces any backoff set with WithBackoff.
 func RetryInterval(interval time.Duration) Option {
+	return WithBackoff(Backoff{
+		Initial: interval,
+		Max:     interval,
+	})
+}
+
+// IOTimeout sets the longest any single operation with the mug may take
+// before failing with ErrTimeout.  A timeout of 0 means no limit beyond the
+// context passed in.
+func IOTimeout(timeout time.Duration) Option {
 	return OptionFunc(func(mug *Mug) error {
-		mug.interval = interval
+		mug.timeout = timeout
 		return nil
 	})
 }
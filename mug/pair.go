@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	bt "tinygo.org/x/bluetooth"
+)
+
+var (
+	ErrPairingNotSupported = errors.New("mug does not support pairing")
+	ErrPairingRejected     = errors.New("mug rejected the pairing key")
+	ErrKeyNotFound         = errors.New("key not found")
+)
+
+// The length of the key given to the mug when pairing.
+const keyLength = 20
+
+// Key is the key material from pairing with a mug.
+type Key struct {
+	// Device is the key the mug provides.  If the mug is reset, the device
+	// key changes and the mug needs to be paired again.
+	Device []byte `json:"device"`
+
+	// User is the key given to the mug when pairing.  It is written to the
+	// mug each time it connects.
+	User []byte `json:"user"`
+}
+
+// KeyStore stores the keys from pairing so they can be reused when the mug
+// reconnects.
+type KeyStore interface {
+	// Load returns the key for the mug or ErrKeyNotFound.
+	Load(address bt.Address) (Key, error)
+
+	// Save stores the key for the mug.
+	Save(address bt.Address, key Key) error
+}
+
+// WithKeyStore sets the store used for the pairing keys.  By default the keys
+// are only kept in memory.
+func WithKeyStore(keys KeyStore) Option {
+	return OptionFunc(func(mug *Mug) error {
+		if keys == nil {
+			return ErrInvalidInput
+		}
+		mug.keys = keys
+		return nil
+	})
+}
+
+// PairingError is returned when pairing with a mug fails.
+type PairingError struct {
+	Address bt.Address
+
+	// Op is the step of pairing that failed.
+	Op string
+
+	Err error
+}
+
+func (e *PairingError) Error() string {
+	return fmt.Sprintf("pairing with %s failed to %s: %v", e.Address.String(), e.Op, e.Err)
+}
+
+func (e *PairingError) Unwrap() error {
+	return e.Err
+}
+
+// Pair pairs with the connected mug, which some mugs need before accepting
+// writes.  A key already in the key store is reused, otherwise a new key is
+// created and saved.  Pairing again is only needed if the mug is reset since
+// the saved key is given to the mug each time it connects.
+//
+// The errors returned are *PairingError values that wrap the cause, such as
+// ErrNotConnected, ErrPairingNotSupported or ErrPairingRejected.
+//
+// Pairing is experimental.  The key exchange over the KEY_0 and KEY_1
+// characteristics is not documented by Ember and has only been tested
+// against the simulator, not real hardware.
+func (m *Mug) Pair(ctx context.Context) error {
+	m.m.Lock()
+	address := m.address
+	connected := m.connected
+	supported := m.apis[mugApi_KEY_0].characteristic != nil &&
+		m.apis[mugApi_KEY_1].characteristic != nil
+	m.m.Unlock()
+
+	fail := func(op string, err error) error {
+		m.logger.Warn("pairing failed", addressAttr(address), "op", op, errAttr(err))
+		return &PairingError{
+			Address: address,
+			Op:      op,
+			Err:     err,
+		}
+	}
+
+	if !connected {
+		return fail("connect", ErrNotConnected)
+	}
+	if !supported {
+		return fail("find the keys", ErrPairingNotSupported)
+	}
+
+	device, _, err := m.io(ctx, m, mugApi_KEY_1, 0)
+	if err != nil {
+		return fail("read the device key", err)
+	}
+
+	key, err := m.keys.Load(address)
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return fail("load the key", err)
+	}
+
+	// A missing key or a new device key means starting over.
+	if err != nil || !bytes.Equal(key.Device, device) {
+		key = Key{
+			Device: append([]byte{}, device...),
+			User:   make([]byte, keyLength),
+		}
+		if _, err := rand.Read(key.User); err != nil {
+			return fail("create the key", err)
+		}
+	}
+
+	got, _, err := m.io(ctx, m, mugApi_KEY_0, 0, key.User)
+	if err != nil {
+		return fail("write the key", err)
+	}
+	if !bytes.Equal(got, key.User) {
+		return fail("write the key", ErrPairingRejected)
+	}
+
+	if err := m.keys.Save(address, key); err != nil {
+		return fail("save the key", err)
+	}
+
+	m.logger.Info("paired with mug", addressAttr(address))
+
+	return nil
+}
+
+// restoreKey gives the saved key to the mug after it connects.  Mugs without
+// a saved key, or that were reset since pairing, are left unpaired.
+func (m *Mug) restoreKey(ctx context.Context) error {
+	m.m.Lock()
+	address := m.address
+	supported := m.apis[mugApi_KEY_0].characteristic != nil &&
+		m.apis[mugApi_KEY_1].characteristic != nil
+	m.m.Unlock()
+
+	if !supported {
+		return nil
+	}
+
+	key, err := m.keys.Load(address)
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	device, _, err := m.io(ctx, m, mugApi_KEY_1, 0)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(device, key.Device) {
+		m.logger.Info("mug was reset and needs to be paired again", addressAttr(address))
+		return nil
+	}
+
+	got, _, err := m.io(ctx, m, mugApi_KEY_0, 0, key.User)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got, key.User) {
+		return ErrPairingRejected
+	}
+
+	return nil
+}
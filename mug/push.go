@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// PushEventType is the kind of event the mug pushed.
+type PushEventType int
+
+const (
+	PushUnknown PushEventType = iota
+	PushBattery
+	PushCharging
+	PushDischarging
+	PushTargetChanged
+	PushDrinkChanged
+	PushLiquidLevelChanged
+	PushStateChanged
+)
+
+var pushEventTypeMap = map[byte]PushEventType{
+	NOTIFY_BATTERY:              PushBattery,
+	NOTIFY_CHARGHING:            PushCharging,
+	NOTIFY_DISCHARGING:          PushDischarging,
+	NOTIFY_TARGET_CHANGED:       PushTargetChanged,
+	NOTIFY_DRINK_CHANGED:        PushDrinkChanged,
+	NOTIFY_LIQUID_LEVEL_CHANGED: PushLiquidLevelChanged,
+	NOTIFY_STATE_CHANGED:        PushStateChanged,
+}
+
+var pushEventTypeStringMap = map[PushEventType]string{
+	PushUnknown:            "Unknown",
+	PushBattery:            "Battery",
+	PushCharging:           "Charging",
+	PushDischarging:        "Discharging",
+	PushTargetChanged:      "TargetChanged",
+	PushDrinkChanged:       "DrinkChanged",
+	PushLiquidLevelChanged: "LiquidLevelChanged",
+	PushStateChanged:       "StateChanged",
+}
+
+func (t PushEventType) String() string {
+	if rv, ok := pushEventTypeStringMap[t]; ok {
+		return rv
+	}
+
+	return fmt.Sprintf("Unknown (%d)", t)
+}
+
+// PushEvent is an event the mug pushed.  Raw is the event exactly as the mug
+// sent it, which is mostly useful for the PushUnknown events.
+type PushEvent struct {
+	Type PushEventType
+	Raw  []byte
+}
+
+func pushEventFromData(buf []byte) PushEvent {
+	return PushEvent{
+		Type: pushEventTypeMap[buf[0]],
+		Raw:  append([]byte{}, buf...),
+	}
+}
+
+type PushEventListener interface {
+	OnPushEvent(PushEvent)
+}
+
+// PushEventFunc is a convenience type for implementing the PushEventListener
+// interface with a function.
+type PushEventFunc func(PushEvent)
+
+func (f PushEventFunc) OnPushEvent(e PushEvent) {
+	f(e)
+}
+
+// AddPushEventListener adds a listener that is called with each event the
+// mug pushes.  The events are queued for the listener, see ListenerOption.
+// The oldest events are dropped if the listener falls behind, unless another
+// policy is chosen.
+func (m *Mug) AddPushEventListener(l PushEventListener, opts ...ListenerOption) CancelFunc {
+	return addListener(&m.pushListeners, newEventConfig(opts), &m.listenerCounters, nil, l.OnPushEvent)
+}
+
+// PushEvents returns a channel of the events the mug pushes.  The channel is
+// closed when the context is done.  If the channel is not drained, the
+// oldest events are dropped once the listener's queue is full.
+func (m *Mug) PushEvents(ctx context.Context) <-chan PushEvent {
+	var (
+		lock   sync.Mutex
+		closed bool
+	)
+
+	ch := make(chan PushEvent, 16)
+	cancel := m.AddPushEventListener(PushEventFunc(func(e PushEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if closed {
+			return
+		}
+
+		select {
+		case ch <- e:
+		case <-ctx.Done():
+		}
+	}))
+
+	go func() {
+		<-ctx.Done()
+		cancel()
+
+		lock.Lock()
+		closed = true
+		close(ch)
+		lock.Unlock()
+	}()
+
+	return ch
+}
+
+// PushEventSeq returns an iterator over the events the mug pushes.  The
+// iteration ends when the context is done or the loop exits.
+func (m *Mug) PushEventSeq(ctx context.Context) iter.Seq[PushEvent] {
+	return func(yield func(PushEvent) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		for e := range m.PushEvents(ctx) {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func (m *Mug) notifyPushEvent(e PushEvent) {
+	notify(&m.pushListeners, e)
+}
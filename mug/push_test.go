@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (m *Mug) hasPushListeners() bool {
+	var found bool
+	m.pushListeners.Visit(func(*queue[PushEvent]) {
+		found = true
+	})
+	return found
+}
+
+func TestMug_PushEvents(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	m, err := New(WithTransport(TransportFunc(func(ctx context.Context) (*Connection, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})))
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := m.PushEvents(ctx)
+
+	pushed := [][]byte{
+		{NOTIFY_BATTERY},
+		{NOTIFY_CHARGHING},
+		{NOTIFY_DISCHARGING},
+		{NOTIFY_TARGET_CHANGED},
+		{NOTIFY_DRINK_CHANGED},
+		{NOTIFY_UNSURE, 0x01},
+		{NOTIFY_LIQUID_LEVEL_CHANGED},
+		{NOTIFY_STATE_CHANGED},
+		{},
+		{0x42},
+	}
+	for _, buf := range pushed {
+		m.onPushEvent(buf)
+	}
+
+	want := []PushEvent{
+		{Type: PushBattery, Raw: []byte{NOTIFY_BATTERY}},
+		{Type: PushCharging, Raw: []byte{NOTIFY_CHARGHING}},
+		{Type: PushDischarging, Raw: []byte{NOTIFY_DISCHARGING}},
+		{Type: PushTargetChanged, Raw: []byte{NOTIFY_TARGET_CHANGED}},
+		{Type: PushDrinkChanged, Raw: []byte{NOTIFY_DRINK_CHANGED}},
+		{Type: PushUnknown, Raw: []byte{NOTIFY_UNSURE, 0x01}},
+		{Type: PushLiquidLevelChanged, Raw: []byte{NOTIFY_LIQUID_LEVEL_CHANGED}},
+		{Type: PushStateChanged, Raw: []byte{NOTIFY_STATE_CHANGED}},
+		{Type: PushUnknown, Raw: []byte{0x42}},
+	}
+	for _, w := range want {
+		select {
+		case got := <-events:
+			assert.Equal(w, got)
+		case <-time.After(time.Second):
+			require.FailNow("timed out waiting for a push event")
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(ok)
+	case <-time.After(time.Second):
+		require.FailNow("timed out waiting for the channel to close")
+	}
+
+	// Events after the cancel are not delivered and do not block.
+	m.onPushEvent([]byte{NOTIFY_BATTERY})
+}
+
+func TestMug_PushEventSeq(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	m, err := New(WithTransport(TransportFunc(func(ctx context.Context) (*Connection, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})))
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	go func() {
+		// Wait for the iterator to subscribe before pushing.
+		for !m.hasPushListeners() {
+			time.Sleep(time.Millisecond)
+		}
+		m.onPushEvent([]byte{NOTIFY_DRINK_CHANGED})
+		m.onPushEvent([]byte{NOTIFY_STATE_CHANGED})
+	}()
+
+	var got []PushEventType
+	for e := range m.PushEventSeq(ctx) {
+		got = append(got, e.Type)
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	assert.Equal([]PushEventType{PushDrinkChanged, PushStateChanged}, got)
+	assert.Equal("StateChanged", got[1].String())
+}
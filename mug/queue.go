@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/xmidt-org/eventor"
+)
+
+// OverflowPolicy is what a listener's queue does when it is full.
+type OverflowPolicy int
+
+const (
+	// CoalesceLatest replaces the newest queued event with the latest one.
+	// Change events are merged so no change is lost, only the intermediate
+	// values.
+	CoalesceLatest OverflowPolicy = iota
+
+	// DropOldest drops the oldest queued event to make room.
+	DropOldest
+
+	// Block waits for the listener to make room.  A slow listener using
+	// Block stalls the mug, and a listener using Block must not call the
+	// mug or it can deadlock.
+	Block
+)
+
+var overflowPolicyStringMap = map[OverflowPolicy]string{
+	CoalesceLatest: "CoalesceLatest",
+	DropOldest:     "DropOldest",
+	Block:          "Block",
+}
+
+func (p OverflowPolicy) String() string {
+	if rv, ok := overflowPolicyStringMap[p]; ok {
+		return rv
+	}
+
+	return fmt.Sprintf("Unknown (%d)", p)
+}
+
+const defaultQueueSize = 16
+
+// ListenerOption configures how events are delivered to a listener.
+type ListenerOption func(*listenerConfig)
+
+type listenerConfig struct {
+	size   int
+	policy OverflowPolicy
+	fields []Field
+}
+
+// QueueSize sets how many events are queued for the listener.  The default
+// is 16.  Sizes less than 1 are treated as 1.
+func QueueSize(size int) ListenerOption {
+	return func(c *listenerConfig) {
+		c.size = max(size, 1)
+	}
+}
+
+// OnOverflow sets what happens when the listener's queue is full.  The
+// default is CoalesceLatest.
+func OnOverflow(policy OverflowPolicy) ListenerOption {
+	return func(c *listenerConfig) {
+		c.policy = policy
+	}
+}
+
+// Fields limits a MugChangeListener to changes of the fields.  No fields
+// means all fields.  It has no effect on other listeners.
+func Fields(fields ...Field) ListenerOption {
+	return func(c *listenerConfig) {
+		c.fields = append(c.fields, fields...)
+	}
+}
+
+func newListenerConfig(opts []ListenerOption) listenerConfig {
+	return newConfig(CoalesceLatest, opts)
+}
+
+// newEventConfig is the config for listeners of discrete events, such as
+// push or connection events, where coalescing would lose events.
+func newEventConfig(opts []ListenerOption) listenerConfig {
+	return newConfig(DropOldest, opts)
+}
+
+func newConfig(policy OverflowPolicy, opts []ListenerOption) listenerConfig {
+	c := listenerConfig{
+		size:   defaultQueueSize,
+		policy: policy,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	c.fields = slices.Clone(c.fields)
+
+	return c
+}
+
+// ListenerStats are the totals for the events sent to the listeners.
+type ListenerStats struct {
+	// Delivered is the number of events delivered to listeners.
+	Delivered uint64
+
+	// Dropped is the number of events that were dropped or coalesced
+	// because a listener fell behind.
+	Dropped uint64
+}
+
+type listenerCounters struct {
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// ListenerStats returns the totals for the events sent to the listeners.
+func (m *Mug) ListenerStats() ListenerStats {
+	return ListenerStats{
+		Delivered: m.listenerCounters.delivered.Load(),
+		Dropped:   m.listenerCounters.dropped.Load(),
+	}
+}
+
+// queue is a bounded queue of events for a single listener.  The events are
+// delivered in order by a goroutine that only runs while there are events
+// queued, so the sender is never blocked by the listener unless the policy
+// is Block.
+type queue[T any] struct {
+	m        sync.Mutex
+	space    *sync.Cond
+	items    []T
+	size     int
+	policy   OverflowPolicy
+	merge    func(older, newer T) T
+	deliver  func(T)
+	counters *listenerCounters
+	running  bool
+	closed   bool
+}
+
+func newQueue[T any](c listenerConfig, counters *listenerCounters, merge func(T, T) T, deliver func(T)) *queue[T] {
+	q := queue[T]{
+		size:     c.size,
+		policy:   c.policy,
+		merge:    merge,
+		deliver:  deliver,
+		counters: counters,
+	}
+	q.space = sync.NewCond(&q.m)
+
+	return &q
+}
+
+// push queues the event, applying the overflow policy if the queue is full.
+func (q *queue[T]) push(v T) {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	for !q.closed && q.policy == Block && len(q.items) >= q.size {
+		q.space.Wait()
+	}
+	if q.closed {
+		return
+	}
+
+	if len(q.items) >= q.size {
+		q.counters.dropped.Add(1)
+		switch q.policy {
+		case DropOldest:
+			q.items = slices.Delete(q.items, 0, 1)
+		default:
+			last := len(q.items) - 1
+			if q.merge != nil {
+				v = q.merge(q.items[last], v)
+			}
+			q.items = q.items[:last]
+		}
+	}
+
+	q.items = append(q.items, v)
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+}
+
+func (q *queue[T]) run() {
+	for {
+		q.m.Lock()
+		if q.closed || len(q.items) == 0 {
+			q.running = false
+			q.m.Unlock()
+			return
+		}
+		v := q.items[0]
+		q.items = slices.Delete(q.items, 0, 1)
+		q.space.Broadcast()
+		q.m.Unlock()
+
+		q.deliver(v)
+		q.counters.delivered.Add(1)
+	}
+}
+
+// close drops any queued events and releases any blocked senders.
+func (q *queue[T]) close() {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	q.closed = true
+	q.items = nil
+	q.space.Broadcast()
+}
+
+// addListener queues the events for the listener and adds the queue to the
+// listeners.
+func addListener[T any](e *eventor.Eventor[*queue[T]], c listenerConfig, counters *listenerCounters, merge func(T, T) T, deliver func(T)) CancelFunc {
+	q := newQueue(c, counters, merge, deliver)
+	cancel := e.Add(q)
+	return func() {
+		// The queue is closed first so a sender blocked on it lets go of
+		// the listeners before the listener is removed.
+		q.close()
+		cancel()
+	}
+}
+
+// notify queues the event for each of the listeners.
+func notify[T any](e *eventor.Eventor[*queue[T]], v T) {
+	e.Visit(func(q *queue[T]) {
+		q.push(v)
+	})
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		description string
+		opts        []ListenerOption
+		merge       func(int, int) int
+		want        []int
+		dropped     uint64
+	}{
+		{
+			description: "coalesce latest",
+			want:        []int{0, 1, 4},
+			opts:        []ListenerOption{QueueSize(2)},
+			dropped:     2,
+		}, {
+			description: "coalesce latest with merge",
+			opts:        []ListenerOption{QueueSize(2)},
+			merge:       func(a, b int) int { return a*10 + b },
+			want:        []int{0, 1, 234},
+			dropped:     2,
+		}, {
+			description: "drop oldest",
+			opts:        []ListenerOption{QueueSize(2), OnOverflow(DropOldest)},
+			want:        []int{0, 3, 4},
+			dropped:     2,
+		}, {
+			description: "block",
+			opts:        []ListenerOption{QueueSize(0), OnOverflow(Block)},
+			want:        []int{0, 1, 2, 3, 4},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var counters listenerCounters
+			gate := make(chan struct{})
+			got := make(chan int, 10)
+			q := newQueue(newListenerConfig(tc.opts), &counters, tc.merge, func(v int) {
+				<-gate
+				got <- v
+			})
+
+			// The first event is taken by the delivery goroutine, which
+			// waits on the gate so the rest back up.
+			q.push(0)
+			assert.Eventually(func() bool {
+				q.m.Lock()
+				defer q.m.Unlock()
+				return len(q.items) == 0
+			}, time.Second, time.Millisecond)
+
+			pushed := make(chan struct{})
+			go func() {
+				defer close(pushed)
+				for i := 1; i < 5; i++ {
+					q.push(i)
+				}
+			}()
+			if tc.dropped > 0 {
+				<-pushed
+			}
+			close(gate)
+			<-pushed
+
+			for _, want := range tc.want {
+				assert.Equal(want, receive(t, got))
+			}
+			assert.Eventually(func() bool {
+				return counters.delivered.Load() == uint64(len(tc.want))
+			}, time.Second, time.Millisecond)
+			assert.Equal(tc.dropped, counters.dropped.Load())
+		})
+	}
+}
+
+func TestQueue_close(t *testing.T) {
+	assert := assert.New(t)
+
+	var counters listenerCounters
+	gate := make(chan struct{})
+	q := newQueue(newListenerConfig([]ListenerOption{QueueSize(1), OnOverflow(Block)}), &counters, nil, func(int) {
+		<-gate
+	})
+
+	q.push(0)
+	q.push(1)
+
+	// Closing releases a blocked sender.
+	pushed := make(chan struct{})
+	go func() {
+		q.push(2)
+		close(pushed)
+	}()
+	q.close()
+	receive(t, pushed)
+	close(gate)
+
+	assert.Eventually(func() bool {
+		return counters.delivered.Load() == 1
+	}, time.Second, time.Millisecond)
+	assert.Zero(counters.dropped.Load())
+}
+
+func TestMug_ListenerStats(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newIdleMug(t)
+
+	gate := make(chan struct{})
+	m.AddMugListener(MugListenerFunc(func(MugInfo) {
+		<-gate
+	}), QueueSize(1), OnOverflow(DropOldest))
+
+	// A stuck listener does not block the mug.
+	for i := 0; i < 5; i++ {
+		m.dispatch()
+	}
+	close(gate)
+
+	// Depending on when the delivery starts, 1 or 2 are delivered.
+	assert.Eventually(func() bool {
+		stats := m.ListenerStats()
+		return stats.Delivered+stats.Dropped == 5
+	}, time.Second, time.Millisecond)
+	assert.GreaterOrEqual(m.ListenerStats().Dropped, uint64(3))
+}
+
+func TestMug_cancelBlockedListener(t *testing.T) {
+	m := newIdleMug(t)
+
+	gate := make(chan struct{})
+	defer close(gate)
+	stuck := make(chan struct{}, 1)
+	cancel := m.AddMugListener(MugListenerFunc(func(MugInfo) {
+		stuck <- struct{}{}
+		<-gate
+	}), QueueSize(1), OnOverflow(Block))
+
+	// The first is taken by the stuck listener and the second fills the
+	// queue.
+	m.dispatch()
+	receive(t, stuck)
+	m.dispatch()
+
+	// The third blocks the dispatch while it is visiting the listeners.
+	dispatched := make(chan struct{})
+	go func() {
+		defer close(dispatched)
+		m.dispatch()
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// Cancelling releases the blocked dispatch instead of waiting on it.
+	cancelled := make(chan struct{})
+	go func() {
+		cancel()
+		close(cancelled)
+	}()
+	receive(t, cancelled)
+	receive(t, dispatched)
+}
+
+func TestMug_slowPushListener(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newIdleMug(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Nothing reads the events, so they back up.
+	events := m.PushEvents(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			m.onPushEvent([]byte{NOTIFY_BATTERY})
+		}
+	}()
+
+	// The notification handler is never blocked by the listener.
+	receive(t, done)
+	assert.Eventually(func() bool {
+		return len(events) == cap(events)
+	}, time.Second, time.Millisecond)
+	assert.Eventually(func() bool {
+		return m.ListenerStats().Dropped > 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestOverflowPolicy_String(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("DropOldest", DropOldest.String())
+	assert.Equal("Unknown (9)", OverflowPolicy(9).String())
+}
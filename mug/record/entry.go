@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package record captures the traffic between a mug.Mug and the mug it is
+// connected to, and replays captured sessions back into a mug.Mug.
+//
+// A capture is a file of JSON lines, one Entry per line, so it can be read
+// and edited with ordinary tools.
+package record
+
+import (
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidCapture   = errors.New("invalid capture")
+	ErrNoCharacteristic = errors.New("characteristic not in capture")
+)
+
+// Op is the kind of operation an Entry describes.
+type Op string
+
+const (
+	OpConnect    Op = "connect"
+	OpDisconnect Op = "disconnect"
+	OpRead       Op = "read"
+	OpWrite      Op = "write"
+	OpNotify     Op = "notify"
+)
+
+// Entry is a single captured operation.
+type Entry struct {
+	// Time is when the operation happened.
+	Time time.Time `json:"time"`
+
+	// Op is the operation.
+	Op Op `json:"op"`
+
+	// ID is the characteristic id using the same numbering as the mug package.
+	ID int `json:"id,omitempty"`
+
+	// Address is the address of the mug for connect operations.
+	Address string `json:"address,omitempty"`
+
+	// IDs are the characteristic ids the mug provided for connect operations.
+	IDs []int `json:"ids,omitempty"`
+
+	// Services are the Ember service UUIDs the mug provided for connect
+	// operations.
+	Services []string `json:"services,omitempty"`
+
+	// Data is the data read, written or sent by the mug.
+	Data Bytes `json:"data,omitempty"`
+
+	// Err is the error returned by the operation if there was one.
+	Err string `json:"err,omitempty"`
+}
+
+// Bytes is a byte slice that is encoded as hex in the capture.
+type Bytes []byte
+
+func (b Bytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+func (b *Bytes) UnmarshalText(text []byte) error {
+	data, err := hex.DecodeString(string(text))
+	if err != nil {
+		return errors.Join(ErrInvalidCapture, err)
+	}
+	*b = data
+	return nil
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/schmidtw/muggo/mug/sim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	m   sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.buf.String()
+}
+
+type watcher struct {
+	connected chan bool
+	infos     chan mug.MugInfo
+}
+
+func newMug(t *testing.T, opts ...mug.Option) (*mug.Mug, *watcher) {
+	w := watcher{
+		connected: make(chan bool, 10),
+		infos:     make(chan mug.MugInfo, 100),
+	}
+
+	opts = append(opts,
+		mug.WithChangeConnectionListener(
+			event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+				w.connected <- cc.Connected
+			})),
+	)
+
+	m, err := mug.New(opts...)
+	require.NoError(t, err)
+	m.AddMugListener(mug.MugListenerFunc(func(mi mug.MugInfo) {
+		w.infos <- mi
+	}))
+
+	return m, &w
+}
+
+func (w *watcher) waitConnected(t *testing.T, want bool) {
+	t.Helper()
+	select {
+	case got := <-w.connected:
+		require.Equal(t, want, got)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for connection change")
+	}
+}
+
+func (w *watcher) wait(t *testing.T, match func(mug.MugInfo) bool) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for {
+		select {
+		case mi := <-w.infos:
+			if match(mi) {
+				return
+			}
+		case <-ctx.Done():
+			require.FailNow(t, "timed out waiting for mug info")
+		}
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := sim.New(sim.WithDrink(50), sim.WithTarget(55))
+	require.NoError(err)
+
+	var capture syncBuffer
+	m, w := newMug(t, mug.WithTransport(s), To(&capture))
+
+	m.Start()
+	w.waitConnected(t, true)
+	want := m.All()
+
+	s.Step(time.Minute)
+	w.wait(t, func(mi mug.MugInfo) bool { return mi.Drink == 53 })
+
+	s.PlaceOnCoaster(false)
+	w.wait(t, func(mi mug.MugInfo) bool { return !mi.Battery.Charging })
+
+	s.Disconnect()
+	w.waitConnected(t, false)
+	m.Stop()
+
+	// Wait for the recorder to catch the disconnect.
+	require.Eventually(func() bool {
+		return strings.Contains(capture.String(), `"op":"disconnect"`)
+	}, time.Second, time.Millisecond)
+
+	// Now replay the session into a new mug.
+	r, err := NewReplayer(strings.NewReader(capture.String()))
+	require.NoError(err)
+
+	m, w = newMug(t, mug.WithTransport(r))
+	m.Start()
+	defer m.Stop()
+
+	w.waitConnected(t, true)
+	assert.Equal(want, m.All())
+
+	require.True(r.Next())
+	w.wait(t, func(mi mug.MugInfo) bool { return mi.Drink == 53 })
+
+	// The push events are handled in the background, so wait for the
+	// discharge rather than checking once the mug disconnects.
+	for r.Next() {
+	}
+	w.wait(t, func(mi mug.MugInfo) bool { return !mi.Battery.Charging })
+	w.waitConnected(t, false)
+}
+
+func TestNewReplayer(t *testing.T) {
+	_, err := NewReplayer(strings.NewReader(`{"op":"read","data":"zz"}`))
+	assert.ErrorIs(t, err, ErrInvalidCapture)
+
+	_, err = NewReplayer(strings.NewReader(`{"op":`))
+	assert.ErrorIs(t, err, ErrInvalidCapture)
+}
+
+func TestReplayer_Connect(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	capture := strings.Join([]string{
+		`{"op":"connect","address":"0f3c5b2a-7e1d-4c5e-9a40-6c1f1b2d3e4f","ids":[1],"services":["` + mug.EmberTravelMugMainServiceUUID + `"]}`,
+		`{"op":"read","id":1,"data":"01"}`,
+		`{"op":"write","id":1,"data":"02"}`,
+		`{"op":"notify","id":1,"data":"03"}`,
+	}, "\n")
+
+	r, err := NewReplayer(strings.NewReader(capture))
+	require.NoError(err)
+
+	conn, err := r.Connect(context.Background())
+	require.NoError(err)
+	assert.Equal("00:00:00:00:00:00", conn.Address.String())
+	require.Len(conn.ServiceUUIDs, 1)
+	assert.Equal(mug.EmberTravelMugMainServiceUUID, conn.ServiceUUIDs[0].String())
+	require.Len(conn.Characteristics, 1)
+
+	// The write is not what the mug reported, so the read is kept.
+	data := make([]byte, 4)
+	n, err := conn.Characteristics[0].Read(data)
+	require.NoError(err)
+	assert.Equal([]byte{0x01}, data[:n])
+
+	// The mug reports the pushed value from then on.
+	require.True(r.Next())
+	n, err = conn.Characteristics[0].Read(data)
+	require.NoError(err)
+	assert.Equal([]byte{0x03}, data[:n])
+}
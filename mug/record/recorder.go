@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+)
+
+// Recorder is a mug.Transport that records all of the traffic passing
+// through the transport it wraps.
+type Recorder struct {
+	m    sync.Mutex
+	next mug.Transport
+	enc  *json.Encoder
+	now  func() time.Time
+}
+
+var _ mug.Transport = (*Recorder)(nil)
+
+// NewRecorder creates a Recorder that writes the traffic of the next
+// transport to w.
+func NewRecorder(next mug.Transport, w io.Writer) *Recorder {
+	return &Recorder{
+		next: next,
+		enc:  json.NewEncoder(w),
+		now:  time.Now,
+	}
+}
+
+// To returns a mug option that records all the traffic of the mug to w.
+func To(w io.Writer) mug.Option {
+	return mug.WrapTransport(func(next mug.Transport) mug.Transport {
+		return NewRecorder(next, w)
+	})
+}
+
+func (r *Recorder) Connect(ctx context.Context) (*mug.Connection, error) {
+	conn, err := r.next.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := mug.Connection{
+		Address:      conn.Address,
+		ServiceUUIDs: conn.ServiceUUIDs,
+		Disconnect:   conn.Disconnect,
+	}
+
+	ids := make([]int, 0, len(conn.Characteristics))
+	for _, c := range conn.Characteristics {
+		ids = append(ids, mug.CharacteristicID(c.UUID()))
+		rv.Characteristics = append(rv.Characteristics, &recordedCharacteristic{
+			Characteristic: c,
+			r:              r,
+			id:             mug.CharacteristicID(c.UUID()),
+		})
+	}
+
+	services := make([]string, 0, len(conn.ServiceUUIDs))
+	for _, uuid := range conn.ServiceUUIDs {
+		services = append(services, uuid.String())
+	}
+
+	r.record(Entry{
+		Op:       OpConnect,
+		Address:  conn.Address.String(),
+		IDs:      ids,
+		Services: services,
+	})
+
+	// Record the disconnect before passing it on so it is always recorded
+	// before the next connect.
+	if conn.Disconnected != nil {
+		disconnected := make(chan struct{})
+		rv.Disconnected = disconnected
+		go func() {
+			<-conn.Disconnected
+			r.record(Entry{Op: OpDisconnect})
+			close(disconnected)
+		}()
+	}
+
+	return &rv, nil
+}
+
+func (r *Recorder) record(e Entry) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	e.Time = r.now()
+	_ = r.enc.Encode(e)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+type recordedCharacteristic struct {
+	mug.Characteristic
+	r  *Recorder
+	id int
+}
+
+func (c *recordedCharacteristic) Read(data []byte) (int, error) {
+	n, err := c.Characteristic.Read(data)
+	c.r.record(Entry{
+		Op:   OpRead,
+		ID:   c.id,
+		Data: append(Bytes{}, data[:max(n, 0)]...),
+		Err:  errString(err),
+	})
+	return n, err
+}
+
+func (c *recordedCharacteristic) WriteWithoutResponse(p []byte) (int, error) {
+	n, err := c.Characteristic.WriteWithoutResponse(p)
+	c.r.record(Entry{
+		Op:   OpWrite,
+		ID:   c.id,
+		Data: append(Bytes{}, p...),
+		Err:  errString(err),
+	})
+	return n, err
+}
+
+func (c *recordedCharacteristic) EnableNotifications(callback func(buf []byte)) error {
+	return c.Characteristic.EnableNotifications(func(buf []byte) {
+		c.r.record(Entry{
+			Op:   OpNotify,
+			ID:   c.id,
+			Data: append(Bytes{}, buf...),
+		})
+		callback(buf)
+	})
+}
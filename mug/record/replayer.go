@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+	bt "tinygo.org/x/bluetooth"
+)
+
+// Replayer is a mug.Transport that plays a captured session back into a
+// mug.Mug.
+//
+// Reads return the value the mug reported at the current point in the
+// capture.  The capture is advanced one push event at a time with Next(), or
+// in (scaled) real time with Play(), so a replay is deterministic.
+type Replayer struct {
+	m       sync.Mutex
+	entries []Entry
+	pos     int
+
+	values map[int]Entry
+	notify map[int]func([]byte)
+
+	connected    bool
+	disconnected chan struct{}
+}
+
+var _ mug.Transport = (*Replayer)(nil)
+
+// NewReplayer reads a capture written by a Recorder.
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	var entries []Entry
+
+	dec := json.NewDecoder(r)
+	for {
+		var e Entry
+		err := dec.Decode(&e)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Join(ErrInvalidCapture, err)
+		}
+		entries = append(entries, e)
+	}
+
+	return &Replayer{
+		entries: entries,
+	}, nil
+}
+
+// Connect replays the next connection in the capture.  If there are no more
+// connections, Connect blocks until the context is canceled.  Addresses that
+// are not MAC addresses, like the UUIDs macOS uses, are replayed as the zero
+// address.
+func (r *Replayer) Connect(ctx context.Context) (*mug.Connection, error) {
+	r.m.Lock()
+	for ; r.pos < len(r.entries); r.pos++ {
+		if r.entries[r.pos].Op == OpConnect {
+			break
+		}
+	}
+
+	if r.pos >= len(r.entries) || r.connected {
+		r.m.Unlock()
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	defer r.m.Unlock()
+
+	e := r.entries[r.pos]
+	r.pos++
+
+	var mac bt.MACAddress
+	if addr, err := bt.ParseMAC(e.Address); err == nil {
+		mac.MAC = addr
+	}
+
+	services := make([]bt.UUID, 0, len(e.Services))
+	for _, s := range e.Services {
+		uuid, err := bt.ParseUUID(s)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidCapture, err)
+		}
+		services = append(services, uuid)
+	}
+
+	r.connected = true
+	r.disconnected = make(chan struct{})
+	r.values = make(map[int]Entry)
+	r.notify = make(map[int]func([]byte))
+	r.apply()
+
+	conn := mug.Connection{
+		Address:      bt.Address{MACAddress: mac},
+		ServiceUUIDs: services,
+		Disconnected: r.disconnected,
+	}
+	for _, id := range e.IDs {
+		conn.Characteristics = append(conn.Characteristics, &replayedCharacteristic{
+			r:    r,
+			id:   id,
+			uuid: mug.CharacteristicUUID(id),
+		})
+	}
+
+	return &conn, nil
+}
+
+// Next advances the replay to the next push event or disconnect and delivers
+// it.  Next returns false when the current connection has no more events.
+func (r *Replayer) Next() bool {
+	r.m.Lock()
+
+	if !r.connected || r.pos >= len(r.entries) {
+		r.m.Unlock()
+		return false
+	}
+
+	e := r.entries[r.pos]
+	switch e.Op {
+	case OpNotify:
+		r.values[e.ID] = e
+		r.pos++
+		r.apply()
+		callback := r.notify[e.ID]
+		r.m.Unlock()
+
+		if callback != nil {
+			callback(append([]byte{}, e.Data...))
+		}
+		return true
+
+	case OpDisconnect:
+		r.pos++
+		r.connected = false
+		close(r.disconnected)
+		r.m.Unlock()
+		return true
+	}
+
+	// The next entry is the start of another connection.
+	r.m.Unlock()
+	return false
+}
+
+// Play replays the current connection using the recorded timing.  The speed
+// scales the timing, so 2 plays twice as fast as recorded.
+func (r *Replayer) Play(ctx context.Context, speed float64) error {
+	if speed <= 0 {
+		return fmt.Errorf("%w: speed must be positive", mug.ErrInvalidInput)
+	}
+
+	for {
+		r.m.Lock()
+		if r.pos >= len(r.entries) || r.pos == 0 {
+			r.m.Unlock()
+			return nil
+		}
+		delay := r.entries[r.pos].Time.Sub(r.entries[r.pos-1].Time)
+		r.m.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(float64(delay) / speed)):
+		}
+
+		if !r.Next() {
+			return nil
+		}
+	}
+}
+
+// apply moves through the reads and writes up to the next push event or
+// disconnect so the values match what the mug reported at that point.  Only
+// the values the mug reported are used, the writes are skipped since the mug
+// may not have taken them.  The lock must be held.
+func (r *Replayer) apply() {
+	for ; r.pos < len(r.entries); r.pos++ {
+		e := r.entries[r.pos]
+		switch e.Op {
+		case OpRead:
+			r.values[e.ID] = e
+		case OpWrite:
+		default:
+			return
+		}
+	}
+}
+
+func (r *Replayer) read(id int, data []byte) (int, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	e, ok := r.values[id]
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrNoCharacteristic, id)
+	}
+
+	if e.Err != "" {
+		return 0, errors.New(e.Err)
+	}
+
+	return copy(data, e.Data), nil
+}
+
+func (r *Replayer) write(id int, p []byte) (int, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.values[id] = Entry{
+		Op:   OpWrite,
+		ID:   id,
+		Data: append(Bytes{}, p...),
+	}
+
+	return len(p), nil
+}
+
+type replayedCharacteristic struct {
+	r    *Replayer
+	id   int
+	uuid bt.UUID
+}
+
+func (c *replayedCharacteristic) UUID() bt.UUID {
+	return c.uuid
+}
+
+func (c *replayedCharacteristic) Read(data []byte) (int, error) {
+	return c.r.read(c.id, data)
+}
+
+func (c *replayedCharacteristic) WriteWithoutResponse(p []byte) (int, error) {
+	return c.r.write(c.id, p)
+}
+
+func (c *replayedCharacteristic) GetMTU() (uint16, error) {
+	return 512, nil
+}
+
+func (c *replayedCharacteristic) EnableNotifications(callback func(buf []byte)) error {
+	c.r.m.Lock()
+	defer c.r.m.Unlock()
+
+	c.r.notify[c.id] = callback
+	return nil
+}
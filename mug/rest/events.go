@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// How often an idle stream sends something so proxies keep it open.
+const keepAlive = 15 * time.Second
+
+var upgrader = websocket.Upgrader{
+	// The API has no cookies or credentials, so any page may follow the mug.
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// getEvents streams the events as Server-Sent Events.
+func (h *Handler) getEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// The writes come from the pinger as well as the events.
+	var (
+		lock sync.Mutex
+		werr error
+	)
+	write := func(format string, args ...any) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if werr == nil {
+			_, werr = fmt.Fprintf(w, format, args...)
+			flusher.Flush()
+		}
+		return werr
+	}
+
+	// Comments keep the connection open while the mug is quiet.
+	go pinger(ctx, func() error {
+		return write(": ping\n\n")
+	})
+
+	id, resume := lastEventID(r.Header.Get("Last-Event-ID"), r.URL.Query().Get("last_event_id"))
+	err := h.stream.follow(ctx, id, resume, func(e Event) error {
+		return write("id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+	})
+	h.streamDone(r, err)
+}
+
+// getWebSocket streams the events as JSON messages over a WebSocket.
+func (h *Handler) getWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied.
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// The client doesn't send anything, but reading is needed to see the
+	// close and the pongs.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	go pinger(ctx, func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(keepAlive))
+	})
+
+	id, resume := lastEventID(r.Header.Get("Last-Event-ID"), r.URL.Query().Get("last_event_id"))
+	err = h.stream.follow(ctx, id, resume, func(e Event) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(keepAlive))
+		return conn.WriteJSON(e)
+	})
+	h.streamDone(r, err)
+
+	_ = conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(time.Second))
+}
+
+// pinger calls ping periodically until the context is done or ping fails.
+func pinger(ctx context.Context, ping func() error) {
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ping() != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *Handler) streamDone(r *http.Request, err error) {
+	if err != nil {
+		h.logger.Debug("stream ended",
+			slog.String("path", r.URL.Path),
+			slog.Any("err", err))
+	}
+}
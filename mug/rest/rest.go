@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rest exposes a mug over HTTP with JSON.
+//
+// The resources are:
+//
+//	GET /mug            the mug information
+//	GET /mug/device     the device information
+//	PUT /mug/target     a temperature such as "135F", "57.5C" or 57.5 (°C)
+//	PUT /mug/units      "C" or "F"
+//	PUT /mug/name       the name of the mug
+//	PUT /mug/led        a color in the "#rrggbb" or "#rrggbbaa" form
+//	GET /mug/events     the events as Server-Sent Events
+//	GET /mug/ws         the events as WebSocket JSON messages
+//
+// The PUT requests return the value read back from the mug.
+//
+// The events are an Info each time the mug information is updated and a
+// Connection each time the connection state changes.  A stream starts with
+// an Info of the current state, which is numbered like any other event and
+// so is seen by the other streams too.  Each event has an id that is one more
+// than the last, and a stream resumes after the id in the Last-Event-ID
+// header or the last_event_id query parameter.  If the events after that id
+// are no longer kept, the stream starts over with the current state.
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"image/color"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/units"
+)
+
+// The largest request body accepted.
+const maxBody = 4096
+
+// Handler serves the mug over HTTP.
+type Handler struct {
+	m      *mug.Mug
+	mux    *http.ServeMux
+	stream *stream
+	logger *slog.Logger
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a Handler for the mug.  A nil logger uses
+// slog.Default().
+func NewHandler(m *mug.Mug, logger *slog.Logger) *Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	h := Handler{
+		m:      m,
+		mux:    http.NewServeMux(),
+		stream: newStream(m),
+		logger: logger,
+	}
+
+	h.mux.HandleFunc("GET /mug", h.getInfo)
+	h.mux.HandleFunc("GET /mug/device", h.getDevice)
+	h.mux.HandleFunc("PUT /mug/target", h.putTarget)
+	h.mux.HandleFunc("PUT /mug/units", h.putUnits)
+	h.mux.HandleFunc("PUT /mug/name", h.putName)
+	h.mux.HandleFunc("PUT /mug/led", h.putLED)
+	h.mux.HandleFunc("GET /mug/events", h.getEvents)
+	h.mux.HandleFunc("GET /mug/ws", h.getWebSocket)
+
+	return &h
+}
+
+// Close stops the Handler from following the mug and ends the event streams.
+// It is safe to call more than once.
+func (h *Handler) Close() {
+	h.stream.close()
+}
+
+// Handle adds another handler to the mux, so the daemon can serve more than
+// the mug API.
+func (h *Handler) Handle(pattern string, handler http.Handler) {
+	h.mux.Handle(pattern, handler)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) getInfo(w http.ResponseWriter, r *http.Request) {
+	h.reply(w, r, newInfo(h.m, h.m.All()), nil)
+}
+
+func (h *Handler) getDevice(w http.ResponseWriter, r *http.Request) {
+	di, err := h.m.DeviceInfoContext(r.Context())
+	if err != nil {
+		h.reply(w, r, nil, err)
+		return
+	}
+
+	h.reply(w, r, newDevice(*di), nil)
+}
+
+func (h *Handler) putTarget(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	if err := decode(r, &body); err != nil {
+		h.reply(w, r, nil, err)
+		return
+	}
+
+	// The target can be a number in °C or any string that ParseTemperature
+	// accepts.
+	s := string(body)
+	if err := json.Unmarshal(body, &s); err != nil {
+		s = string(body)
+	}
+
+	temp, err := units.ParseTemperature(s)
+	if err == nil {
+		err = mug.CheckTarget(temp)
+	}
+	if err != nil {
+		h.reply(w, r, nil, err)
+		return
+	}
+
+	got, err := h.m.TargetContext(r.Context(), temp)
+	h.reply(w, r, newTemperature(got), err)
+}
+
+func (h *Handler) putUnits(w http.ResponseWriter, r *http.Request) {
+	var unit string
+	if err := decode(r, &unit); err != nil {
+		h.reply(w, r, nil, err)
+		return
+	}
+
+	got, err := h.m.UnitsContext(r.Context(), units.TemperatureUnit(strings.ToUpper(unit)))
+	h.reply(w, r, string(got), err)
+}
+
+func (h *Handler) putName(w http.ResponseWriter, r *http.Request) {
+	var name string
+	if err := decode(r, &name); err != nil {
+		h.reply(w, r, nil, err)
+		return
+	}
+
+	got, err := h.m.NameContext(r.Context(), name)
+	h.reply(w, r, got, err)
+}
+
+func (h *Handler) putLED(w http.ResponseWriter, r *http.Request) {
+	var led LED
+	if err := decode(r, &led); err != nil {
+		h.reply(w, r, nil, err)
+		return
+	}
+
+	got, err := h.m.LedContext(r.Context(), color.NRGBA(led))
+	if err != nil {
+		h.reply(w, r, nil, err)
+		return
+	}
+	h.reply(w, r, LED(*got), nil)
+}
+
+// decode reads the JSON request body into v.
+func decode(r *http.Request, v any) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBody+1))
+	if err != nil {
+		return err
+	}
+	if len(body) > maxBody {
+		return errors.Join(mug.ErrInvalidInput, errors.New("request body too large"))
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.Join(mug.ErrInvalidInput, err)
+	}
+
+	return nil
+}
+
+// reply writes the value as JSON, or the error with the matching status.
+func (h *Handler) reply(w http.ResponseWriter, r *http.Request, v any, err error) {
+	status := http.StatusOK
+	if err != nil {
+		status = StatusCode(err)
+		v = Error{Error: err.Error()}
+		h.logger.Debug("request failed",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Any("err", err))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// StatusCode returns the HTTP status code for the error from the mug.
+func StatusCode(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, mug.ErrInvalidInput), errors.Is(err, units.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, mug.ErrNotSupported):
+		return http.StatusNotImplemented
+	case errors.Is(err, mug.ErrNotConnected):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, mug.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, mug.ErrPairingRejected), errors.Is(err, mug.ErrPairingNotSupported):
+		return http.StatusConflict
+	}
+
+	return http.StatusBadGateway
+}
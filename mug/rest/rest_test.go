@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/schmidtw/muggo/mug/sim"
+	"github.com/schmidtw/muggo/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// startMug starts a mug connected to a simulator, and returns a server for
+// it.
+func startMug(t *testing.T, opts ...sim.Option) *httptest.Server {
+	t.Helper()
+
+	s, err := sim.New(opts...)
+	require.NoError(t, err)
+
+	connected := make(chan bool, 1)
+	m, err := mug.New(
+		mug.WithTransport(s),
+		mug.WithLogger(nil),
+		mug.WithChangeConnectionListener(
+			event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+				select {
+				case connected <- cc.Connected:
+				default:
+				}
+			})),
+	)
+	require.NoError(t, err)
+
+	m.Start()
+	t.Cleanup(m.Stop)
+
+	select {
+	case got := <-connected:
+		require.True(t, got)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting to connect")
+	}
+
+	h := NewHandler(m, discard)
+	t.Cleanup(h.Close)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func do(t *testing.T, srv *httptest.Server, method, path, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	buf, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, strings.TrimSpace(string(buf))
+}
+
+func TestHandler_get(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	srv := startMug(t, sim.WithDrink(60), sim.WithTarget(57))
+
+	status, body := do(t, srv, "GET", "/mug", "")
+	require.Equal(http.StatusOK, status)
+
+	var info Info
+	require.NoError(json.Unmarshal([]byte(body), &info))
+	assert.Equal("00:00:5E:00:53:01", info.Address)
+	assert.True(info.Connected)
+	assert.Equal("Connected", info.Connection)
+	assert.Equal("Ember Sim", info.Name)
+	assert.Equal(Temperature{C: 60, F: 140}, info.Drink)
+	assert.Equal(57.0, info.Target.C)
+	assert.Equal("C", info.Units)
+	assert.Equal(100.0, info.LiquidLevel.Percent)
+	assert.Equal("#ff7f00ff", info.LED.String())
+	assert.True(info.Capabilities.LED)
+	assert.Equal("SIM0000001", info.Device.Serial)
+
+	status, body = do(t, srv, "GET", "/mug/device", "")
+	require.Equal(http.StatusOK, status)
+	var device Device
+	require.NoError(json.Unmarshal([]byte(body), &device))
+	assert.Equal(info.Device, device)
+}
+
+func TestHandler_put(t *testing.T) {
+	tests := []struct {
+		description string
+		travel      bool
+		path        string
+		body        string
+		status      int
+		want        string
+	}{
+		{
+			description: "target in fahrenheit",
+			path:        "/mug/target",
+			body:        `"131F"`,
+			status:      http.StatusOK,
+			want:        `{"c":55,"f":131}`,
+		}, {
+			description: "target as a number",
+			path:        "/mug/target",
+			body:        `56`,
+			status:      http.StatusOK,
+			want:        `{"c":56,"f":132.8}`,
+		}, {
+			description: "invalid target",
+			path:        "/mug/target",
+			body:        `"hot"`,
+			status:      http.StatusBadRequest,
+		}, {
+			description: "target too cold",
+			path:        "/mug/target",
+			body:        `49.5`,
+			status:      http.StatusBadRequest,
+		}, {
+			description: "target too hot",
+			path:        "/mug/target",
+			body:        `"150F"`,
+			status:      http.StatusBadRequest,
+		}, {
+			description: "units",
+			path:        "/mug/units",
+			body:        `"f"`,
+			status:      http.StatusOK,
+			want:        `"F"`,
+		}, {
+			description: "invalid units",
+			path:        "/mug/units",
+			body:        `"K"`,
+			status:      http.StatusBadRequest,
+		}, {
+			description: "name",
+			path:        "/mug/name",
+			body:        `"Coffee"`,
+			status:      http.StatusOK,
+			want:        `"Coffee"`,
+		}, {
+			description: "led",
+			path:        "/mug/led",
+			body:        `"#00ff00"`,
+			status:      http.StatusOK,
+			want:        `"#00ff00ff"`,
+		}, {
+			description: "invalid led",
+			path:        "/mug/led",
+			body:        `"green"`,
+			status:      http.StatusBadRequest,
+		}, {
+			description: "the travel mug has no led",
+			travel:      true,
+			path:        "/mug/led",
+			body:        `"#00ff00"`,
+			status:      http.StatusNotImplemented,
+		}, {
+			description: "not json",
+			path:        "/mug/name",
+			body:        `Coffee`,
+			status:      http.StatusBadRequest,
+		}, {
+			description: "too large",
+			path:        "/mug/name",
+			body:        `"` + strings.Repeat("x", maxBody) + `"`,
+			status:      http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			model := sim.Ceramic
+			if tc.travel {
+				model = sim.Travel
+			}
+			srv := startMug(t, sim.WithModel(model))
+
+			status, body := do(t, srv, "PUT", tc.path, tc.body)
+			assert.Equal(tc.status, status, body)
+			if tc.want != "" {
+				assert.JSONEq(tc.want, body)
+			}
+			if tc.status != http.StatusOK {
+				var e Error
+				assert.NoError(json.Unmarshal([]byte(body), &e))
+				assert.NotEmpty(e.Error)
+			}
+		})
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: nil, want: http.StatusOK},
+		{err: mug.ErrInvalidInput, want: http.StatusBadRequest},
+		{err: units.ErrInvalidInput, want: http.StatusBadRequest},
+		{err: mug.ErrNotSupported, want: http.StatusNotImplemented},
+		{err: fmt.Errorf("wrapped: %w", mug.ErrNotConnected), want: http.StatusServiceUnavailable},
+		{err: mug.ErrTimeout, want: http.StatusGatewayTimeout},
+		{err: mug.ErrPairingRejected, want: http.StatusConflict},
+		{err: errors.New("bluetooth"), want: http.StatusBadGateway},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, StatusCode(tc.err), "%v", tc.err)
+	}
+}
+
+func TestParseLED(t *testing.T) {
+	assert := assert.New(t)
+
+	got, err := ParseLED("#FF800080")
+	assert.NoError(err)
+	assert.Equal(LED{R: 0xff, G: 0x80, B: 0x00, A: 0x80}, got)
+
+	_, err = ParseLED("#12345")
+	assert.ErrorIs(err, mug.ErrInvalidInput)
+	_, err = ParseLED("#zzzzzz")
+	assert.ErrorIs(err, mug.ErrInvalidInput)
+}
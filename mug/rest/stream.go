@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+)
+
+// The number of events kept so a client can resume where it left off.
+const historySize = 256
+
+// The types of streamed events.
+const (
+	// InfoEvent carries an Info each time the mug information is updated.
+	InfoEvent = "info"
+
+	// ConnectionEvent carries a Connection each time the connection state
+	// changes.
+	ConnectionEvent = "connection"
+)
+
+// Event is a streamed event.  The ids increase by one with each event, so a
+// client can tell if it missed any.
+type Event struct {
+	ID   uint64          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Connection is the data of a ConnectionEvent.
+type Connection struct {
+	Address   string  `json:"address"`
+	Connected bool    `json:"connected"`
+	State     string  `json:"state"`
+	Previous  string  `json:"previous"`
+	Error     string  `json:"error,omitempty"`
+	Attempt   int     `json:"attempt,omitempty"`
+	RetryIn   float64 `json:"retry_in_seconds,omitempty"`
+}
+
+func newConnection(sc event.StateChange) Connection {
+	c := Connection{
+		Address:   sc.Address.String(),
+		Connected: sc.State == event.Connected,
+		State:     sc.State.String(),
+		Previous:  sc.Previous.String(),
+		Attempt:   sc.Attempt,
+		RetryIn:   sc.Delay.Seconds(),
+	}
+	if sc.Err != nil {
+		c.Error = sc.Err.Error()
+	}
+
+	return c
+}
+
+// stream numbers the events from the mug and keeps the recent ones so the
+// clients can resume.
+type stream struct {
+	m       sync.Mutex
+	seq     uint64
+	history []Event
+	changed chan struct{}
+
+	// done is closed when the stream is closed, which ends the streams
+	// being followed.
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// snapshot returns the current mug information.
+	snapshot func() any
+	cancel   []mug.CancelFunc
+}
+
+func newStream(m *mug.Mug) *stream {
+	s := stream{
+		changed: make(chan struct{}),
+		done:    make(chan struct{}),
+		snapshot: func() any {
+			return newInfo(m, m.All())
+		},
+	}
+
+	s.cancel = append(s.cancel,
+		m.AddMugListener(mug.MugListenerFunc(func(info mug.MugInfo) {
+			_, _ = s.publish(InfoEvent, newInfo(m, info))
+		}), mug.QueueSize(historySize), mug.OnOverflow(mug.DropOldest)),
+		m.AddStateChangeListener(event.StateChangeFunc(func(sc event.StateChange) {
+			_, _ = s.publish(ConnectionEvent, newConnection(sc))
+		})),
+	)
+
+	return &s
+}
+
+// close stops following the mug and ends the streams being followed.
+func (s *stream) close() {
+	s.closeOnce.Do(func() {
+		for _, cancel := range s.cancel {
+			cancel()
+		}
+		close(s.done)
+	})
+}
+
+// publish numbers the event and adds it to the history.
+func (s *stream) publish(typ string, data any) (Event, error) {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.seq++
+	e := Event{
+		ID:   s.seq,
+		Type: typ,
+		Data: buf,
+	}
+	s.history = append(s.history, e)
+	if len(s.history) > historySize {
+		s.history = s.history[len(s.history)-historySize:]
+	}
+
+	close(s.changed)
+	s.changed = make(chan struct{})
+
+	return e, nil
+}
+
+// after returns the events after the id and a channel that is closed when
+// there are more.  If the events after the id are no longer kept, or the id
+// is unknown, a snapshot of the mug information is published and the
+// events from it on are returned instead.
+func (s *stream) after(id uint64, resume bool) ([]Event, <-chan struct{}) {
+	if resume {
+		if events, changed, ok := s.kept(id); ok {
+			return events, changed
+		}
+	}
+
+	// The snapshot may talk to the mug, so it is taken without the lock.
+	// Publishing it gives it an id of its own for the client to resume
+	// from.
+	e, err := s.publish(InfoEvent, s.snapshot())
+	if err == nil {
+		if events, changed, ok := s.kept(e.ID - 1); ok {
+			return events, changed
+		}
+	}
+
+	// The history turned over while publishing, so wait for the next event
+	// and try again.
+	s.m.Lock()
+	defer s.m.Unlock()
+	return nil, s.changed
+}
+
+// kept returns the events after the id if they are all still kept.
+func (s *stream) kept(id uint64) ([]Event, <-chan struct{}, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	oldest := s.seq + 1
+	if len(s.history) > 0 {
+		oldest = s.history[0].ID
+	}
+
+	if oldest-1 <= id && id <= s.seq {
+		events := s.history[len(s.history)-int(s.seq-id):]
+		return append([]Event(nil), events...), s.changed, true
+	}
+
+	return nil, nil, false
+}
+
+// follow sends the events after the id, or a snapshot if not resuming, and
+// then each new event until the context is done, the stream is closed or
+// send fails.
+func (s *stream) follow(ctx context.Context, id uint64, resume bool, send func(Event) error) error {
+	for {
+		events, changed := s.after(id, resume)
+		for _, e := range events {
+			if err := send(e); err != nil {
+				return err
+			}
+			id = e.ID
+		}
+		resume = true
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.done:
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+// lastEventID returns the id the client last saw, if it is resuming.
+func lastEventID(values ...string) (uint64, bool) {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(v, 10, 64)
+		return id, err == nil
+	}
+
+	return 0, false
+}
@@ -0,0 +1,296 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package rest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStream returns a stream with more events than it keeps.
+func newTestStream(t *testing.T) *stream {
+	s := stream{
+		changed: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	s.snapshot = func() any {
+		// The snapshot may talk to the mug, so the lock must not be held.
+		if assert.True(t, s.m.TryLock(), "the snapshot was taken with the lock held") {
+			s.m.Unlock()
+		}
+		return "now"
+	}
+	for i := 0; i < historySize+10; i++ {
+		_, err := s.publish(InfoEvent, i)
+		require.NoError(t, err)
+	}
+
+	return &s
+}
+
+func Test_stream_after(t *testing.T) {
+	last := uint64(historySize + 10)
+
+	tests := []struct {
+		description string
+		id          uint64
+		resume      bool
+		first       uint64
+		count       int
+		snapshot    bool
+	}{
+		{
+			description: "a new stream gets a snapshot",
+			first:       last + 1,
+			count:       1,
+			snapshot:    true,
+		}, {
+			description: "up to date",
+			id:          last,
+			resume:      true,
+		}, {
+			description: "a few behind",
+			id:          last - 2,
+			resume:      true,
+			first:       last - 1,
+			count:       2,
+		}, {
+			description: "the oldest kept",
+			id:          last - historySize,
+			resume:      true,
+			first:       last - historySize + 1,
+			count:       historySize,
+		}, {
+			description: "too far behind",
+			id:          last - historySize - 1,
+			resume:      true,
+			first:       last + 1,
+			count:       1,
+			snapshot:    true,
+		}, {
+			description: "from the future, like before a restart",
+			id:          last + 10,
+			resume:      true,
+			first:       last + 1,
+			count:       1,
+			snapshot:    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			s := newTestStream(t)
+			events, changed := s.after(tc.id, tc.resume)
+			assert.NotNil(changed)
+			assert.Equal(historySize, len(s.history))
+			require.Len(t, events, tc.count)
+			if tc.count == 0 {
+				return
+			}
+
+			// The ids always follow on from the last.
+			for i, e := range events {
+				assert.Equal(tc.first+uint64(i), e.ID)
+			}
+			assert.Equal(s.seq, events[len(events)-1].ID)
+			if tc.snapshot {
+				assert.JSONEq(`"now"`, string(events[0].Data))
+			} else {
+				assert.JSONEq(strconv.Itoa(int(tc.first-1)), string(events[0].Data))
+			}
+		})
+	}
+}
+
+func Test_stream_close(t *testing.T) {
+	s := newTestStream(t)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.follow(context.Background(), 0, false, func(Event) error {
+			return nil
+		})
+	}()
+
+	// Closing ends the streams being followed, even though their contexts
+	// are not done.
+	s.close()
+	s.close()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		require.FailNow(t, "the stream did not end when closed")
+	}
+}
+
+func Test_lastEventID(t *testing.T) {
+	assert := assert.New(t)
+
+	id, ok := lastEventID("", "12")
+	assert.Equal(uint64(12), id)
+	assert.True(ok)
+
+	id, ok = lastEventID("7", "12")
+	assert.Equal(uint64(7), id)
+	assert.True(ok)
+
+	_, ok = lastEventID("", "")
+	assert.False(ok)
+	_, ok = lastEventID("x")
+	assert.False(ok)
+}
+
+// sse reads Server-Sent Events from the server.
+type sse struct {
+	resp   *http.Response
+	reader *bufio.Reader
+}
+
+func openSSE(t *testing.T, srv *httptest.Server, lastID string) *sse {
+	t.Helper()
+
+	// The timeout keeps a missing event from hanging the test.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", srv.URL+"/mug/events", nil)
+	require.NoError(t, err)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	return &sse{resp: resp, reader: bufio.NewReader(resp.Body)}
+}
+
+func (s *sse) next(t *testing.T) Event {
+	t.Helper()
+
+	var e Event
+	for {
+		line, err := s.reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "":
+			if e.Type != "" {
+				return e
+			}
+		case strings.HasPrefix(line, "id: "):
+			e.ID, err = strconv.ParseUint(strings.TrimPrefix(line, "id: "), 10, 64)
+			require.NoError(t, err)
+		case strings.HasPrefix(line, "event: "):
+			e.Type = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.Data = json.RawMessage(strings.TrimPrefix(line, "data: "))
+		}
+	}
+}
+
+func target(t *testing.T, e Event) float64 {
+	t.Helper()
+
+	require.Equal(t, InfoEvent, e.Type)
+	var info Info
+	require.NoError(t, json.Unmarshal(e.Data, &info))
+	return info.Target.C
+}
+
+// nextTarget skips events until an info event with the target.
+func nextTarget(t *testing.T, next func() Event, want float64) Event {
+	t.Helper()
+
+	for {
+		e := next()
+		if e.Type == InfoEvent && target(t, e) == want {
+			return e
+		}
+	}
+}
+
+func TestHandler_events(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := startMug(t)
+
+	stream := openSSE(t, srv, "")
+	first := stream.next(t)
+	assert.Equal(57.0, target(t, first))
+
+	for _, temp := range []string{"55", "56"} {
+		status, _ := do(t, srv, "PUT", "/mug/target", temp)
+		assert.Equal(http.StatusOK, status)
+	}
+
+	e55 := nextTarget(t, func() Event { return stream.next(t) }, 55)
+	e56 := nextTarget(t, func() Event { return stream.next(t) }, 56)
+	assert.Greater(e56.ID, e55.ID)
+
+	// Resuming replays what came after.
+	resumed := openSSE(t, srv, fmt.Sprint(e55.ID))
+	want := e55.ID + 1
+	got := nextTarget(t, func() Event {
+		e := resumed.next(t)
+		assert.Equal(want, e.ID)
+		want++
+		return e
+	}, 56)
+	assert.Equal(e56.ID, got.ID)
+}
+
+func TestHandler_webSocket(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	srv := startMug(t)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/mug/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(err)
+	defer conn.Close()
+
+	next := func() Event {
+		var e Event
+		require.NoError(conn.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(conn.ReadJSON(&e))
+		return e
+	}
+
+	first := next()
+	assert.Equal(57.0, target(t, first))
+
+	status, _ := do(t, srv, "PUT", "/mug/target", "55")
+	assert.Equal(http.StatusOK, status)
+	e55 := nextTarget(t, next, 55)
+
+	// Resuming with the query parameter.
+	resumed, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s?last_event_id=%d", url, first.ID), nil)
+	require.NoError(err)
+	defer resumed.Close()
+
+	var e Event
+	require.NoError(resumed.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(resumed.ReadJSON(&e))
+	assert.Equal(first.ID+1, e.ID)
+	assert.LessOrEqual(e.ID, e55.ID)
+}
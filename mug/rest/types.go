@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/units"
+)
+
+// Temperature is a temperature in both units.
+type Temperature struct {
+	C float64 `json:"c"`
+	F float64 `json:"f"`
+}
+
+func newTemperature(t units.Temperature) Temperature {
+	return Temperature{
+		C: t.C(),
+		F: t.F(),
+	}
+}
+
+type Battery struct {
+	Percent     float64     `json:"percent"`
+	Charging    bool        `json:"charging"`
+	Temperature Temperature `json:"temperature"`
+}
+
+type LiquidLevel struct {
+	Raw     byte    `json:"raw"`
+	Percent float64 `json:"percent"`
+	Empty   bool    `json:"empty"`
+}
+
+type Capabilities struct {
+	DisplayUnits  bool `json:"display_units"`
+	Volume        bool `json:"volume"`
+	LED           bool `json:"led"`
+	TravelDisplay bool `json:"travel_display"`
+}
+
+type Device struct {
+	Firmware   string `json:"firmware"`
+	Hardware   uint16 `json:"hardware"`
+	Bootloader string `json:"bootloader"`
+	Serial     string `json:"serial"`
+	MugID      string `json:"mug_id"`
+}
+
+func newDevice(di mug.DeviceInfo) Device {
+	return Device{
+		Firmware:   di.Firmware().String(),
+		Hardware:   di.HardwareVersion,
+		Bootloader: di.Bootloader().String(),
+		Serial:     di.SerialNumber,
+		MugID:      di.MugID.String(),
+	}
+}
+
+// Info is the JSON form of the mug information.
+type Info struct {
+	Address      string       `json:"address"`
+	Connected    bool         `json:"connected"`
+	Connection   string       `json:"connection"`
+	Name         string       `json:"name"`
+	Drink        Temperature  `json:"drink"`
+	Target       Temperature  `json:"target"`
+	Units        string       `json:"units"`
+	State        string       `json:"state"`
+	Battery      Battery      `json:"battery"`
+	LiquidLevel  LiquidLevel  `json:"liquid_level"`
+	Volume       float64      `json:"volume_ml"`
+	LED          LED          `json:"led"`
+	Model        string       `json:"model"`
+	Capabilities Capabilities `json:"capabilities"`
+	Device       Device       `json:"device"`
+}
+
+func newInfo(m *mug.Mug, info mug.MugInfo) Info {
+	caps := info.Model.Capabilities()
+
+	return Info{
+		Address:    m.Address().String(),
+		Connected:  m.IsConnected(),
+		Connection: m.ConnectionState().String(),
+		Name:       info.Name,
+		Drink:      newTemperature(info.Drink),
+		Target:     newTemperature(info.Target),
+		Units:      string(info.Units),
+		State:      info.State.String(),
+		Battery: Battery{
+			Percent:     info.Battery.PercentLeft,
+			Charging:    info.Battery.Charging,
+			Temperature: newTemperature(info.Battery.Temp),
+		},
+		LiquidLevel: LiquidLevel{
+			Raw:     info.LiquidLevel.Raw,
+			Percent: info.LiquidLevel.Percent,
+			Empty:   info.LiquidLevel.IsEmpty(),
+		},
+		Volume: info.Volume.ML(),
+		LED:    LED(info.LED),
+		Model:  info.Model.String(),
+		Capabilities: Capabilities{
+			DisplayUnits:  caps.DisplayUnits,
+			Volume:        caps.Volume,
+			LED:           caps.LED,
+			TravelDisplay: caps.TravelDisplay,
+		},
+		Device: newDevice(info.DeviceInfo),
+	}
+}
+
+// LED is a LED color in the #rrggbbaa form.  The alpha is the brightness
+// and may be left off, in which case it is full brightness.
+type LED color.NRGBA
+
+func (l LED) String() string {
+	return fmt.Sprintf("#%02x%02x%02x%02x", l.R, l.G, l.B, l.A)
+}
+
+func (l LED) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.String())
+}
+
+func (l *LED) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errors.Join(mug.ErrInvalidInput, err)
+	}
+
+	led, err := ParseLED(s)
+	if err != nil {
+		return err
+	}
+	*l = led
+	return nil
+}
+
+// ParseLED parses a color in the #rrggbb or #rrggbbaa form.
+func ParseLED(s string) (LED, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) == 6 {
+		s += "ff"
+	}
+
+	var l LED
+	if len(s) != 8 {
+		return l, fmt.Errorf("%w: %q is not a color", mug.ErrInvalidInput, s)
+	}
+	if _, err := fmt.Sscanf(s, "%02x%02x%02x%02x", &l.R, &l.G, &l.B, &l.A); err != nil {
+		return l, fmt.Errorf("%w: %q is not a color", mug.ErrInvalidInput, s)
+	}
+
+	return l, nil
+}
+
+// Error is the body of a failed request.
+type Error struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,1840 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        (unknown)
+// source: mug.proto
+
+package mugv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TemperatureUnit int32
+
+const (
+	TemperatureUnit_TEMPERATURE_UNIT_UNSPECIFIED TemperatureUnit = 0
+	TemperatureUnit_TEMPERATURE_UNIT_CELSIUS     TemperatureUnit = 1
+	TemperatureUnit_TEMPERATURE_UNIT_FAHRENHEIT  TemperatureUnit = 2
+)
+
+// Enum value maps for TemperatureUnit.
+var (
+	TemperatureUnit_name = map[int32]string{
+		0: "TEMPERATURE_UNIT_UNSPECIFIED",
+		1: "TEMPERATURE_UNIT_CELSIUS",
+		2: "TEMPERATURE_UNIT_FAHRENHEIT",
+	}
+	TemperatureUnit_value = map[string]int32{
+		"TEMPERATURE_UNIT_UNSPECIFIED": 0,
+		"TEMPERATURE_UNIT_CELSIUS":     1,
+		"TEMPERATURE_UNIT_FAHRENHEIT":  2,
+	}
+)
+
+func (x TemperatureUnit) Enum() *TemperatureUnit {
+	p := new(TemperatureUnit)
+	*p = x
+	return p
+}
+
+func (x TemperatureUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TemperatureUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_mug_proto_enumTypes[0].Descriptor()
+}
+
+func (TemperatureUnit) Type() protoreflect.EnumType {
+	return &file_mug_proto_enumTypes[0]
+}
+
+func (x TemperatureUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TemperatureUnit.Descriptor instead.
+func (TemperatureUnit) EnumDescriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{0}
+}
+
+type State int32
+
+const (
+	State_STATE_UNSPECIFIED State = 0
+	State_STATE_EMPTY       State = 1
+	State_STATE_FILLING     State = 2
+	State_STATE_COLD        State = 3
+	State_STATE_COOLING     State = 4
+	State_STATE_HEATING     State = 5
+	State_STATE_PERFECT     State = 6
+	State_STATE_HOT         State = 7
+)
+
+// Enum value maps for State.
+var (
+	State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_EMPTY",
+		2: "STATE_FILLING",
+		3: "STATE_COLD",
+		4: "STATE_COOLING",
+		5: "STATE_HEATING",
+		6: "STATE_PERFECT",
+		7: "STATE_HOT",
+	}
+	State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"STATE_EMPTY":       1,
+		"STATE_FILLING":     2,
+		"STATE_COLD":        3,
+		"STATE_COOLING":     4,
+		"STATE_HEATING":     5,
+		"STATE_PERFECT":     6,
+		"STATE_HOT":         7,
+	}
+)
+
+func (x State) Enum() *State {
+	p := new(State)
+	*p = x
+	return p
+}
+
+func (x State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (State) Descriptor() protoreflect.EnumDescriptor {
+	return file_mug_proto_enumTypes[1].Descriptor()
+}
+
+func (State) Type() protoreflect.EnumType {
+	return &file_mug_proto_enumTypes[1]
+}
+
+func (x State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use State.Descriptor instead.
+func (State) EnumDescriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{1}
+}
+
+type ConnectionState int32
+
+const (
+	ConnectionState_CONNECTION_STATE_UNSPECIFIED          ConnectionState = 0
+	ConnectionState_CONNECTION_STATE_DISCONNECTED         ConnectionState = 1
+	ConnectionState_CONNECTION_STATE_ADAPTER_DISABLED     ConnectionState = 2
+	ConnectionState_CONNECTION_STATE_SCANNING             ConnectionState = 3
+	ConnectionState_CONNECTION_STATE_CONNECTING           ConnectionState = 4
+	ConnectionState_CONNECTION_STATE_DISCOVERING_SERVICES ConnectionState = 5
+	ConnectionState_CONNECTION_STATE_CONNECTED            ConnectionState = 6
+	ConnectionState_CONNECTION_STATE_BACKOFF              ConnectionState = 7
+)
+
+// Enum value maps for ConnectionState.
+var (
+	ConnectionState_name = map[int32]string{
+		0: "CONNECTION_STATE_UNSPECIFIED",
+		1: "CONNECTION_STATE_DISCONNECTED",
+		2: "CONNECTION_STATE_ADAPTER_DISABLED",
+		3: "CONNECTION_STATE_SCANNING",
+		4: "CONNECTION_STATE_CONNECTING",
+		5: "CONNECTION_STATE_DISCOVERING_SERVICES",
+		6: "CONNECTION_STATE_CONNECTED",
+		7: "CONNECTION_STATE_BACKOFF",
+	}
+	ConnectionState_value = map[string]int32{
+		"CONNECTION_STATE_UNSPECIFIED":          0,
+		"CONNECTION_STATE_DISCONNECTED":         1,
+		"CONNECTION_STATE_ADAPTER_DISABLED":     2,
+		"CONNECTION_STATE_SCANNING":             3,
+		"CONNECTION_STATE_CONNECTING":           4,
+		"CONNECTION_STATE_DISCOVERING_SERVICES": 5,
+		"CONNECTION_STATE_CONNECTED":            6,
+		"CONNECTION_STATE_BACKOFF":              7,
+	}
+)
+
+func (x ConnectionState) Enum() *ConnectionState {
+	p := new(ConnectionState)
+	*p = x
+	return p
+}
+
+func (x ConnectionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConnectionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_mug_proto_enumTypes[2].Descriptor()
+}
+
+func (ConnectionState) Type() protoreflect.EnumType {
+	return &file_mug_proto_enumTypes[2]
+}
+
+func (x ConnectionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConnectionState.Descriptor instead.
+func (ConnectionState) EnumDescriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{2}
+}
+
+// Temperature is a temperature in both units.
+type Temperature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Celsius    float64 `protobuf:"fixed64,1,opt,name=celsius,proto3" json:"celsius,omitempty"`
+	Fahrenheit float64 `protobuf:"fixed64,2,opt,name=fahrenheit,proto3" json:"fahrenheit,omitempty"`
+}
+
+func (x *Temperature) Reset() {
+	*x = Temperature{}
+	mi := &file_mug_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Temperature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Temperature) ProtoMessage() {}
+
+func (x *Temperature) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Temperature.ProtoReflect.Descriptor instead.
+func (*Temperature) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Temperature) GetCelsius() float64 {
+	if x != nil {
+		return x.Celsius
+	}
+	return 0
+}
+
+func (x *Temperature) GetFahrenheit() float64 {
+	if x != nil {
+		return x.Fahrenheit
+	}
+	return 0
+}
+
+// Color is a LED color.  The alpha is the brightness.
+type Color struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Red   uint32 `protobuf:"varint,1,opt,name=red,proto3" json:"red,omitempty"`
+	Green uint32 `protobuf:"varint,2,opt,name=green,proto3" json:"green,omitempty"`
+	Blue  uint32 `protobuf:"varint,3,opt,name=blue,proto3" json:"blue,omitempty"`
+	Alpha uint32 `protobuf:"varint,4,opt,name=alpha,proto3" json:"alpha,omitempty"`
+}
+
+func (x *Color) Reset() {
+	*x = Color{}
+	mi := &file_mug_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Color) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Color) ProtoMessage() {}
+
+func (x *Color) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Color.ProtoReflect.Descriptor instead.
+func (*Color) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Color) GetRed() uint32 {
+	if x != nil {
+		return x.Red
+	}
+	return 0
+}
+
+func (x *Color) GetGreen() uint32 {
+	if x != nil {
+		return x.Green
+	}
+	return 0
+}
+
+func (x *Color) GetBlue() uint32 {
+	if x != nil {
+		return x.Blue
+	}
+	return 0
+}
+
+func (x *Color) GetAlpha() uint32 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+type Battery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Percent     float64      `protobuf:"fixed64,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	Charging    bool         `protobuf:"varint,2,opt,name=charging,proto3" json:"charging,omitempty"`
+	Temperature *Temperature `protobuf:"bytes,3,opt,name=temperature,proto3" json:"temperature,omitempty"`
+}
+
+func (x *Battery) Reset() {
+	*x = Battery{}
+	mi := &file_mug_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Battery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Battery) ProtoMessage() {}
+
+func (x *Battery) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Battery.ProtoReflect.Descriptor instead.
+func (*Battery) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Battery) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *Battery) GetCharging() bool {
+	if x != nil {
+		return x.Charging
+	}
+	return false
+}
+
+func (x *Battery) GetTemperature() *Temperature {
+	if x != nil {
+		return x.Temperature
+	}
+	return nil
+}
+
+type LiquidLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Raw     uint32  `protobuf:"varint,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	Percent float64 `protobuf:"fixed64,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	Empty   bool    `protobuf:"varint,3,opt,name=empty,proto3" json:"empty,omitempty"`
+}
+
+func (x *LiquidLevel) Reset() {
+	*x = LiquidLevel{}
+	mi := &file_mug_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiquidLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidLevel) ProtoMessage() {}
+
+func (x *LiquidLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidLevel.ProtoReflect.Descriptor instead.
+func (*LiquidLevel) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LiquidLevel) GetRaw() uint32 {
+	if x != nil {
+		return x.Raw
+	}
+	return 0
+}
+
+func (x *LiquidLevel) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *LiquidLevel) GetEmpty() bool {
+	if x != nil {
+		return x.Empty
+	}
+	return false
+}
+
+type Capabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DisplayUnits  bool `protobuf:"varint,1,opt,name=display_units,json=displayUnits,proto3" json:"display_units,omitempty"`
+	Volume        bool `protobuf:"varint,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	Led           bool `protobuf:"varint,3,opt,name=led,proto3" json:"led,omitempty"`
+	TravelDisplay bool `protobuf:"varint,4,opt,name=travel_display,json=travelDisplay,proto3" json:"travel_display,omitempty"`
+}
+
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
+	mi := &file_mug_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Capabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capabilities) ProtoMessage() {}
+
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Capabilities) GetDisplayUnits() bool {
+	if x != nil {
+		return x.DisplayUnits
+	}
+	return false
+}
+
+func (x *Capabilities) GetVolume() bool {
+	if x != nil {
+		return x.Volume
+	}
+	return false
+}
+
+func (x *Capabilities) GetLed() bool {
+	if x != nil {
+		return x.Led
+	}
+	return false
+}
+
+func (x *Capabilities) GetTravelDisplay() bool {
+	if x != nil {
+		return x.TravelDisplay
+	}
+	return false
+}
+
+type Device struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Firmware   string `protobuf:"bytes,1,opt,name=firmware,proto3" json:"firmware,omitempty"`
+	Hardware   uint32 `protobuf:"varint,2,opt,name=hardware,proto3" json:"hardware,omitempty"`
+	Bootloader string `protobuf:"bytes,3,opt,name=bootloader,proto3" json:"bootloader,omitempty"`
+	Serial     string `protobuf:"bytes,4,opt,name=serial,proto3" json:"serial,omitempty"`
+	MugId      string `protobuf:"bytes,5,opt,name=mug_id,json=mugId,proto3" json:"mug_id,omitempty"`
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_mug_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Device) GetFirmware() string {
+	if x != nil {
+		return x.Firmware
+	}
+	return ""
+}
+
+func (x *Device) GetHardware() uint32 {
+	if x != nil {
+		return x.Hardware
+	}
+	return 0
+}
+
+func (x *Device) GetBootloader() string {
+	if x != nil {
+		return x.Bootloader
+	}
+	return ""
+}
+
+func (x *Device) GetSerial() string {
+	if x != nil {
+		return x.Serial
+	}
+	return ""
+}
+
+func (x *Device) GetMugId() string {
+	if x != nil {
+		return x.MugId
+	}
+	return ""
+}
+
+// Info is the mug information.
+type Info struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address      string          `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Connected    bool            `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
+	Connection   ConnectionState `protobuf:"varint,3,opt,name=connection,proto3,enum=muggo.mug.v1.ConnectionState" json:"connection,omitempty"`
+	Name         string          `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Drink        *Temperature    `protobuf:"bytes,5,opt,name=drink,proto3" json:"drink,omitempty"`
+	Target       *Temperature    `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	Units        TemperatureUnit `protobuf:"varint,7,opt,name=units,proto3,enum=muggo.mug.v1.TemperatureUnit" json:"units,omitempty"`
+	State        State           `protobuf:"varint,8,opt,name=state,proto3,enum=muggo.mug.v1.State" json:"state,omitempty"`
+	Battery      *Battery        `protobuf:"bytes,9,opt,name=battery,proto3" json:"battery,omitempty"`
+	LiquidLevel  *LiquidLevel    `protobuf:"bytes,10,opt,name=liquid_level,json=liquidLevel,proto3" json:"liquid_level,omitempty"`
+	VolumeMl     float64         `protobuf:"fixed64,11,opt,name=volume_ml,json=volumeMl,proto3" json:"volume_ml,omitempty"`
+	Led          *Color          `protobuf:"bytes,12,opt,name=led,proto3" json:"led,omitempty"`
+	Model        string          `protobuf:"bytes,13,opt,name=model,proto3" json:"model,omitempty"`
+	Capabilities *Capabilities   `protobuf:"bytes,14,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Device       *Device         `protobuf:"bytes,15,opt,name=device,proto3" json:"device,omitempty"`
+}
+
+func (x *Info) Reset() {
+	*x = Info{}
+	mi := &file_mug_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Info) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Info) ProtoMessage() {}
+
+func (x *Info) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Info.ProtoReflect.Descriptor instead.
+func (*Info) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Info) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Info) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *Info) GetConnection() ConnectionState {
+	if x != nil {
+		return x.Connection
+	}
+	return ConnectionState_CONNECTION_STATE_UNSPECIFIED
+}
+
+func (x *Info) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Info) GetDrink() *Temperature {
+	if x != nil {
+		return x.Drink
+	}
+	return nil
+}
+
+func (x *Info) GetTarget() *Temperature {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *Info) GetUnits() TemperatureUnit {
+	if x != nil {
+		return x.Units
+	}
+	return TemperatureUnit_TEMPERATURE_UNIT_UNSPECIFIED
+}
+
+func (x *Info) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *Info) GetBattery() *Battery {
+	if x != nil {
+		return x.Battery
+	}
+	return nil
+}
+
+func (x *Info) GetLiquidLevel() *LiquidLevel {
+	if x != nil {
+		return x.LiquidLevel
+	}
+	return nil
+}
+
+func (x *Info) GetVolumeMl() float64 {
+	if x != nil {
+		return x.VolumeMl
+	}
+	return 0
+}
+
+func (x *Info) GetLed() *Color {
+	if x != nil {
+		return x.Led
+	}
+	return nil
+}
+
+func (x *Info) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Info) GetCapabilities() *Capabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Info) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+// Connection is a change in the connection state.
+type Connection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address        string          `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	State          ConnectionState `protobuf:"varint,2,opt,name=state,proto3,enum=muggo.mug.v1.ConnectionState" json:"state,omitempty"`
+	Previous       ConnectionState `protobuf:"varint,3,opt,name=previous,proto3,enum=muggo.mug.v1.ConnectionState" json:"previous,omitempty"`
+	Error          string          `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Attempt        int32           `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	RetryInSeconds float64         `protobuf:"fixed64,6,opt,name=retry_in_seconds,json=retryInSeconds,proto3" json:"retry_in_seconds,omitempty"`
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	mi := &file_mug_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Connection) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Connection) GetState() ConnectionState {
+	if x != nil {
+		return x.State
+	}
+	return ConnectionState_CONNECTION_STATE_UNSPECIFIED
+}
+
+func (x *Connection) GetPrevious() ConnectionState {
+	if x != nil {
+		return x.Previous
+	}
+	return ConnectionState_CONNECTION_STATE_UNSPECIFIED
+}
+
+func (x *Connection) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Connection) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *Connection) GetRetryInSeconds() float64 {
+	if x != nil {
+		return x.RetryInSeconds
+	}
+	return 0
+}
+
+type GetInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetInfoRequest) Reset() {
+	*x = GetInfoRequest{}
+	mi := &file_mug_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoRequest) ProtoMessage() {}
+
+func (x *GetInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetInfoRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{8}
+}
+
+type GetDeviceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetDeviceRequest) Reset() {
+	*x = GetDeviceRequest{}
+	mi := &file_mug_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceRequest) ProtoMessage() {}
+
+func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{9}
+}
+
+type GetNameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetNameRequest) Reset() {
+	*x = GetNameRequest{}
+	mi := &file_mug_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNameRequest) ProtoMessage() {}
+
+func (x *GetNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNameRequest.ProtoReflect.Descriptor instead.
+func (*GetNameRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{10}
+}
+
+type SetNameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *SetNameRequest) Reset() {
+	*x = SetNameRequest{}
+	mi := &file_mug_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNameRequest) ProtoMessage() {}
+
+func (x *SetNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNameRequest.ProtoReflect.Descriptor instead.
+func (*SetNameRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type NameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *NameResponse) Reset() {
+	*x = NameResponse{}
+	mi := &file_mug_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameResponse) ProtoMessage() {}
+
+func (x *NameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameResponse.ProtoReflect.Descriptor instead.
+func (*NameResponse) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *NameResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetTargetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetTargetRequest) Reset() {
+	*x = GetTargetRequest{}
+	mi := &file_mug_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTargetRequest) ProtoMessage() {}
+
+func (x *GetTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTargetRequest.ProtoReflect.Descriptor instead.
+func (*GetTargetRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{13}
+}
+
+type SetTargetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Target:
+	//	*SetTargetRequest_Celsius
+	//	*SetTargetRequest_Fahrenheit
+	//	*SetTargetRequest_Text
+	Target isSetTargetRequest_Target `protobuf_oneof:"target"`
+}
+
+func (x *SetTargetRequest) Reset() {
+	*x = SetTargetRequest{}
+	mi := &file_mug_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTargetRequest) ProtoMessage() {}
+
+func (x *SetTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTargetRequest.ProtoReflect.Descriptor instead.
+func (*SetTargetRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{14}
+}
+
+func (m *SetTargetRequest) GetTarget() isSetTargetRequest_Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+func (x *SetTargetRequest) GetCelsius() float64 {
+	if x, ok := x.GetTarget().(*SetTargetRequest_Celsius); ok {
+		return x.Celsius
+	}
+	return 0
+}
+
+func (x *SetTargetRequest) GetFahrenheit() float64 {
+	if x, ok := x.GetTarget().(*SetTargetRequest_Fahrenheit); ok {
+		return x.Fahrenheit
+	}
+	return 0
+}
+
+func (x *SetTargetRequest) GetText() string {
+	if x, ok := x.GetTarget().(*SetTargetRequest_Text); ok {
+		return x.Text
+	}
+	return ""
+}
+
+type isSetTargetRequest_Target interface {
+	isSetTargetRequest_Target()
+}
+
+type SetTargetRequest_Celsius struct {
+	Celsius float64 `protobuf:"fixed64,1,opt,name=celsius,proto3,oneof"`
+}
+
+type SetTargetRequest_Fahrenheit struct {
+	Fahrenheit float64 `protobuf:"fixed64,2,opt,name=fahrenheit,proto3,oneof"`
+}
+
+type SetTargetRequest_Text struct {
+	// text is any temperature that units.ParseTemperature accepts, such as
+	// "135F".
+	Text string `protobuf:"bytes,3,opt,name=text,proto3,oneof"`
+}
+
+func (*SetTargetRequest_Celsius) isSetTargetRequest_Target() {}
+
+func (*SetTargetRequest_Fahrenheit) isSetTargetRequest_Target() {}
+
+func (*SetTargetRequest_Text) isSetTargetRequest_Target() {}
+
+type GetUnitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetUnitsRequest) Reset() {
+	*x = GetUnitsRequest{}
+	mi := &file_mug_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnitsRequest) ProtoMessage() {}
+
+func (x *GetUnitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnitsRequest.ProtoReflect.Descriptor instead.
+func (*GetUnitsRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{15}
+}
+
+type SetUnitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Units TemperatureUnit `protobuf:"varint,1,opt,name=units,proto3,enum=muggo.mug.v1.TemperatureUnit" json:"units,omitempty"`
+}
+
+func (x *SetUnitsRequest) Reset() {
+	*x = SetUnitsRequest{}
+	mi := &file_mug_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUnitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUnitsRequest) ProtoMessage() {}
+
+func (x *SetUnitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUnitsRequest.ProtoReflect.Descriptor instead.
+func (*SetUnitsRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetUnitsRequest) GetUnits() TemperatureUnit {
+	if x != nil {
+		return x.Units
+	}
+	return TemperatureUnit_TEMPERATURE_UNIT_UNSPECIFIED
+}
+
+type UnitsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Units TemperatureUnit `protobuf:"varint,1,opt,name=units,proto3,enum=muggo.mug.v1.TemperatureUnit" json:"units,omitempty"`
+}
+
+func (x *UnitsResponse) Reset() {
+	*x = UnitsResponse{}
+	mi := &file_mug_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitsResponse) ProtoMessage() {}
+
+func (x *UnitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitsResponse.ProtoReflect.Descriptor instead.
+func (*UnitsResponse) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnitsResponse) GetUnits() TemperatureUnit {
+	if x != nil {
+		return x.Units
+	}
+	return TemperatureUnit_TEMPERATURE_UNIT_UNSPECIFIED
+}
+
+type GetLedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetLedRequest) Reset() {
+	*x = GetLedRequest{}
+	mi := &file_mug_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLedRequest) ProtoMessage() {}
+
+func (x *GetLedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLedRequest.ProtoReflect.Descriptor instead.
+func (*GetLedRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{18}
+}
+
+type SetLedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color *Color `protobuf:"bytes,1,opt,name=color,proto3" json:"color,omitempty"`
+}
+
+func (x *SetLedRequest) Reset() {
+	*x = SetLedRequest{}
+	mi := &file_mug_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLedRequest) ProtoMessage() {}
+
+func (x *SetLedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLedRequest.ProtoReflect.Descriptor instead.
+func (*SetLedRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetLedRequest) GetColor() *Color {
+	if x != nil {
+		return x.Color
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_mug_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{20}
+}
+
+type WatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*WatchResponse_Info
+	//	*WatchResponse_Connection
+	Event isWatchResponse_Event `protobuf_oneof:"event"`
+}
+
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
+	mi := &file_mug_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResponse) ProtoMessage() {}
+
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mug_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return file_mug_proto_rawDescGZIP(), []int{21}
+}
+
+func (m *WatchResponse) GetEvent() isWatchResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *WatchResponse) GetInfo() *Info {
+	if x, ok := x.GetEvent().(*WatchResponse_Info); ok {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *WatchResponse) GetConnection() *Connection {
+	if x, ok := x.GetEvent().(*WatchResponse_Connection); ok {
+		return x.Connection
+	}
+	return nil
+}
+
+type isWatchResponse_Event interface {
+	isWatchResponse_Event()
+}
+
+type WatchResponse_Info struct {
+	Info *Info `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type WatchResponse_Connection struct {
+	Connection *Connection `protobuf:"bytes,2,opt,name=connection,proto3,oneof"`
+}
+
+func (*WatchResponse_Info) isWatchResponse_Event() {}
+
+func (*WatchResponse_Connection) isWatchResponse_Event() {}
+
+var File_mug_proto protoreflect.FileDescriptor
+
+var file_mug_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x6d, 0x75, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6d, 0x75, 0x67,
+	0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x22, 0x47, 0x0a, 0x0b, 0x54, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x65, 0x6c, 0x73,
+	0x69, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x63, 0x65, 0x6c, 0x73, 0x69,
+	0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x61, 0x68, 0x72, 0x65, 0x6e, 0x68, 0x65, 0x69, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x66, 0x61, 0x68, 0x72, 0x65, 0x6e, 0x68, 0x65,
+	0x69, 0x74, 0x22, 0x59, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x72,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x67, 0x72, 0x65, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x67, 0x72,
+	0x65, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x62, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x22, 0x7c, 0x0a,
+	0x07, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x3b,
+	0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0b,
+	0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x4f, 0x0a, 0x0b, 0x4c,
+	0x69, 0x71, 0x75, 0x69, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61,
+	0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x84, 0x01, 0x0a,
+	0x0c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x55, 0x6e, 0x69,
+	0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6c, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e,
+	0x74, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x44, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x22, 0x8f, 0x01, 0x0a, 0x06, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x61,
+	0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x68, 0x61,
+	0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x74, 0x6c, 0x6f,
+	0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x6f, 0x6f, 0x74,
+	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x15,
+	0x0a, 0x06, 0x6d, 0x75, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6d, 0x75, 0x67, 0x49, 0x64, 0x22, 0x8c, 0x05, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6d, 0x75, 0x67,
+	0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x64, 0x72, 0x69,
+	0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f,
+	0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x52, 0x05, 0x64, 0x72, 0x69, 0x6e, 0x6b, 0x12, 0x31, 0x0a, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x75, 0x67,
+	0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x33, 0x0a,
+	0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6d,
+	0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x05, 0x75, 0x6e, 0x69,
+	0x74, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2f, 0x0a,
+	0x07, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x07, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x12, 0x3c,
+	0x0a, 0x0c, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52,
+	0x0b, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09,
+	0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x6d, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x6c, 0x12, 0x25, 0x0a, 0x03, 0x6c, 0x65, 0x64,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d,
+	0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x03, 0x6c, 0x65, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x3e, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d,
+	0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d,
+	0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x06, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x22, 0xf0, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x33, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6d,
+	0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x28, 0x0a,
+	0x10, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79, 0x49, 0x6e,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x10, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x10, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x24, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x22, 0x0a, 0x0c, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x70, 0x0a,
+	0x10, 0x53, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x07, 0x63, 0x65, 0x6c, 0x73, 0x69, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x00, 0x52, 0x07, 0x63, 0x65, 0x6c, 0x73, 0x69, 0x75, 0x73, 0x12, 0x20, 0x0a,
+	0x0a, 0x66, 0x61, 0x68, 0x72, 0x65, 0x6e, 0x68, 0x65, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x48, 0x00, 0x52, 0x0a, 0x66, 0x61, 0x68, 0x72, 0x65, 0x6e, 0x68, 0x65, 0x69, 0x74, 0x12,
+	0x14, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22,
+	0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x46, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x55,
+	0x6e, 0x69, 0x74, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x22, 0x44, 0x0a, 0x0d, 0x55, 0x6e,
+	0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x75,
+	0x6e, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6d, 0x75, 0x67,
+	0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73,
+	0x22, 0x0f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x3a, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x0e, 0x0a,
+	0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x7e, 0x0a,
+	0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28,
+	0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d,
+	0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x66, 0x6f,
+	0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x3a, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d,
+	0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0x72, 0x0a,
+	0x0f, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x55, 0x6e, 0x69, 0x74,
+	0x12, 0x20, 0x0a, 0x1c, 0x54, 0x45, 0x4d, 0x50, 0x45, 0x52, 0x41, 0x54, 0x55, 0x52, 0x45, 0x5f,
+	0x55, 0x4e, 0x49, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x45, 0x4d, 0x50, 0x45, 0x52, 0x41, 0x54, 0x55, 0x52,
+	0x45, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x43, 0x45, 0x4c, 0x53, 0x49, 0x55, 0x53, 0x10, 0x01,
+	0x12, 0x1f, 0x0a, 0x1b, 0x54, 0x45, 0x4d, 0x50, 0x45, 0x52, 0x41, 0x54, 0x55, 0x52, 0x45, 0x5f,
+	0x55, 0x4e, 0x49, 0x54, 0x5f, 0x46, 0x41, 0x48, 0x52, 0x45, 0x4e, 0x48, 0x45, 0x49, 0x54, 0x10,
+	0x02, 0x2a, 0x9a, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x45, 0x4d, 0x50, 0x54,
+	0x59, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x49, 0x4c,
+	0x4c, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x43, 0x4f, 0x4c, 0x44, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x43, 0x4f, 0x4f, 0x4c, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x48, 0x45, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x45, 0x52, 0x46, 0x45, 0x43, 0x54, 0x10, 0x06, 0x12,
+	0x0d, 0x0a, 0x09, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x48, 0x4f, 0x54, 0x10, 0x07, 0x2a, 0xa6,
+	0x02, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e,
+	0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x25, 0x0a, 0x21, 0x43, 0x4f, 0x4e, 0x4e, 0x45,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x44, 0x41, 0x50,
+	0x54, 0x45, 0x52, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1d,
+	0x0a, 0x19, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x53, 0x43, 0x41, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x1f, 0x0a,
+	0x1b, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x12, 0x29,
+	0x0a, 0x25, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x53,
+	0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x53, 0x10, 0x05, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x4e,
+	0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x4f,
+	0x4e, 0x4e, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x06, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x4e,
+	0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x42, 0x41,
+	0x43, 0x4b, 0x4f, 0x46, 0x46, 0x10, 0x07, 0x32, 0xf2, 0x05, 0x0a, 0x0a, 0x4d, 0x75, 0x67, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x1c, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x41, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x1e, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x1c, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x53,
+	0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d,
+	0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x46, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1e, 0x2e,
+	0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1e, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x46, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x6d,
+	0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55,
+	0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6d, 0x75,
+	0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x55,
+	0x6e, 0x69, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3a, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x12, 0x1b, 0x2e, 0x6d, 0x75, 0x67,
+	0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e,
+	0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x3a, 0x0a, 0x06,
+	0x53, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x12, 0x1b, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d,
+	0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x42, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x1a, 0x2e, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2e, 0x6d, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x29, 0x5a, 0x27,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x68, 0x6d, 0x69,
+	0x64, 0x74, 0x77, 0x2f, 0x6d, 0x75, 0x67, 0x67, 0x6f, 0x2f, 0x6d, 0x75, 0x67, 0x2f, 0x72, 0x70,
+	0x63, 0x2f, 0x6d, 0x75, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_mug_proto_rawDescOnce sync.Once
+	file_mug_proto_rawDescData = file_mug_proto_rawDesc
+)
+
+func file_mug_proto_rawDescGZIP() []byte {
+	file_mug_proto_rawDescOnce.Do(func() {
+		file_mug_proto_rawDescData = protoimpl.X.CompressGZIP(file_mug_proto_rawDescData)
+	})
+	return file_mug_proto_rawDescData
+}
+
+var file_mug_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_mug_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_mug_proto_goTypes = []any{
+	(TemperatureUnit)(0),     // 0: muggo.mug.v1.TemperatureUnit
+	(State)(0),               // 1: muggo.mug.v1.State
+	(ConnectionState)(0),     // 2: muggo.mug.v1.ConnectionState
+	(*Temperature)(nil),      // 3: muggo.mug.v1.Temperature
+	(*Color)(nil),            // 4: muggo.mug.v1.Color
+	(*Battery)(nil),          // 5: muggo.mug.v1.Battery
+	(*LiquidLevel)(nil),      // 6: muggo.mug.v1.LiquidLevel
+	(*Capabilities)(nil),     // 7: muggo.mug.v1.Capabilities
+	(*Device)(nil),           // 8: muggo.mug.v1.Device
+	(*Info)(nil),             // 9: muggo.mug.v1.Info
+	(*Connection)(nil),       // 10: muggo.mug.v1.Connection
+	(*GetInfoRequest)(nil),   // 11: muggo.mug.v1.GetInfoRequest
+	(*GetDeviceRequest)(nil), // 12: muggo.mug.v1.GetDeviceRequest
+	(*GetNameRequest)(nil),   // 13: muggo.mug.v1.GetNameRequest
+	(*SetNameRequest)(nil),   // 14: muggo.mug.v1.SetNameRequest
+	(*NameResponse)(nil),     // 15: muggo.mug.v1.NameResponse
+	(*GetTargetRequest)(nil), // 16: muggo.mug.v1.GetTargetRequest
+	(*SetTargetRequest)(nil), // 17: muggo.mug.v1.SetTargetRequest
+	(*GetUnitsRequest)(nil),  // 18: muggo.mug.v1.GetUnitsRequest
+	(*SetUnitsRequest)(nil),  // 19: muggo.mug.v1.SetUnitsRequest
+	(*UnitsResponse)(nil),    // 20: muggo.mug.v1.UnitsResponse
+	(*GetLedRequest)(nil),    // 21: muggo.mug.v1.GetLedRequest
+	(*SetLedRequest)(nil),    // 22: muggo.mug.v1.SetLedRequest
+	(*WatchRequest)(nil),     // 23: muggo.mug.v1.WatchRequest
+	(*WatchResponse)(nil),    // 24: muggo.mug.v1.WatchResponse
+}
+var file_mug_proto_depIdxs = []int32{
+	3,  // 0: muggo.mug.v1.Battery.temperature:type_name -> muggo.mug.v1.Temperature
+	2,  // 1: muggo.mug.v1.Info.connection:type_name -> muggo.mug.v1.ConnectionState
+	3,  // 2: muggo.mug.v1.Info.drink:type_name -> muggo.mug.v1.Temperature
+	3,  // 3: muggo.mug.v1.Info.target:type_name -> muggo.mug.v1.Temperature
+	0,  // 4: muggo.mug.v1.Info.units:type_name -> muggo.mug.v1.TemperatureUnit
+	1,  // 5: muggo.mug.v1.Info.state:type_name -> muggo.mug.v1.State
+	5,  // 6: muggo.mug.v1.Info.battery:type_name -> muggo.mug.v1.Battery
+	6,  // 7: muggo.mug.v1.Info.liquid_level:type_name -> muggo.mug.v1.LiquidLevel
+	4,  // 8: muggo.mug.v1.Info.led:type_name -> muggo.mug.v1.Color
+	7,  // 9: muggo.mug.v1.Info.capabilities:type_name -> muggo.mug.v1.Capabilities
+	8,  // 10: muggo.mug.v1.Info.device:type_name -> muggo.mug.v1.Device
+	2,  // 11: muggo.mug.v1.Connection.state:type_name -> muggo.mug.v1.ConnectionState
+	2,  // 12: muggo.mug.v1.Connection.previous:type_name -> muggo.mug.v1.ConnectionState
+	0,  // 13: muggo.mug.v1.SetUnitsRequest.units:type_name -> muggo.mug.v1.TemperatureUnit
+	0,  // 14: muggo.mug.v1.UnitsResponse.units:type_name -> muggo.mug.v1.TemperatureUnit
+	4,  // 15: muggo.mug.v1.SetLedRequest.color:type_name -> muggo.mug.v1.Color
+	9,  // 16: muggo.mug.v1.WatchResponse.info:type_name -> muggo.mug.v1.Info
+	10, // 17: muggo.mug.v1.WatchResponse.connection:type_name -> muggo.mug.v1.Connection
+	11, // 18: muggo.mug.v1.MugService.GetInfo:input_type -> muggo.mug.v1.GetInfoRequest
+	12, // 19: muggo.mug.v1.MugService.GetDevice:input_type -> muggo.mug.v1.GetDeviceRequest
+	13, // 20: muggo.mug.v1.MugService.GetName:input_type -> muggo.mug.v1.GetNameRequest
+	14, // 21: muggo.mug.v1.MugService.SetName:input_type -> muggo.mug.v1.SetNameRequest
+	16, // 22: muggo.mug.v1.MugService.GetTarget:input_type -> muggo.mug.v1.GetTargetRequest
+	17, // 23: muggo.mug.v1.MugService.SetTarget:input_type -> muggo.mug.v1.SetTargetRequest
+	18, // 24: muggo.mug.v1.MugService.GetUnits:input_type -> muggo.mug.v1.GetUnitsRequest
+	19, // 25: muggo.mug.v1.MugService.SetUnits:input_type -> muggo.mug.v1.SetUnitsRequest
+	21, // 26: muggo.mug.v1.MugService.GetLed:input_type -> muggo.mug.v1.GetLedRequest
+	22, // 27: muggo.mug.v1.MugService.SetLed:input_type -> muggo.mug.v1.SetLedRequest
+	23, // 28: muggo.mug.v1.MugService.Watch:input_type -> muggo.mug.v1.WatchRequest
+	9,  // 29: muggo.mug.v1.MugService.GetInfo:output_type -> muggo.mug.v1.Info
+	8,  // 30: muggo.mug.v1.MugService.GetDevice:output_type -> muggo.mug.v1.Device
+	15, // 31: muggo.mug.v1.MugService.GetName:output_type -> muggo.mug.v1.NameResponse
+	15, // 32: muggo.mug.v1.MugService.SetName:output_type -> muggo.mug.v1.NameResponse
+	3,  // 33: muggo.mug.v1.MugService.GetTarget:output_type -> muggo.mug.v1.Temperature
+	3,  // 34: muggo.mug.v1.MugService.SetTarget:output_type -> muggo.mug.v1.Temperature
+	20, // 35: muggo.mug.v1.MugService.GetUnits:output_type -> muggo.mug.v1.UnitsResponse
+	20, // 36: muggo.mug.v1.MugService.SetUnits:output_type -> muggo.mug.v1.UnitsResponse
+	4,  // 37: muggo.mug.v1.MugService.GetLed:output_type -> muggo.mug.v1.Color
+	4,  // 38: muggo.mug.v1.MugService.SetLed:output_type -> muggo.mug.v1.Color
+	24, // 39: muggo.mug.v1.MugService.Watch:output_type -> muggo.mug.v1.WatchResponse
+	29, // [29:40] is the sub-list for method output_type
+	18, // [18:29] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_mug_proto_init() }
+func file_mug_proto_init() {
+	if File_mug_proto != nil {
+		return
+	}
+	file_mug_proto_msgTypes[14].OneofWrappers = []any{
+		(*SetTargetRequest_Celsius)(nil),
+		(*SetTargetRequest_Fahrenheit)(nil),
+		(*SetTargetRequest_Text)(nil),
+	}
+	file_mug_proto_msgTypes[21].OneofWrappers = []any{
+		(*WatchResponse_Info)(nil),
+		(*WatchResponse_Connection)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_mug_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mug_proto_goTypes,
+		DependencyIndexes: file_mug_proto_depIdxs,
+		EnumInfos:         file_mug_proto_enumTypes,
+		MessageInfos:      file_mug_proto_msgTypes,
+	}.Build()
+	File_mug_proto = out.File
+	file_mug_proto_rawDesc = nil
+	file_mug_proto_goTypes = nil
+	file_mug_proto_depIdxs = nil
+}
@@ -0,0 +1,526 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: mug.proto
+
+package mugv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MugService_GetInfo_FullMethodName   = "/muggo.mug.v1.MugService/GetInfo"
+	MugService_GetDevice_FullMethodName = "/muggo.mug.v1.MugService/GetDevice"
+	MugService_GetName_FullMethodName   = "/muggo.mug.v1.MugService/GetName"
+	MugService_SetName_FullMethodName   = "/muggo.mug.v1.MugService/SetName"
+	MugService_GetTarget_FullMethodName = "/muggo.mug.v1.MugService/GetTarget"
+	MugService_SetTarget_FullMethodName = "/muggo.mug.v1.MugService/SetTarget"
+	MugService_GetUnits_FullMethodName  = "/muggo.mug.v1.MugService/GetUnits"
+	MugService_SetUnits_FullMethodName  = "/muggo.mug.v1.MugService/SetUnits"
+	MugService_GetLed_FullMethodName    = "/muggo.mug.v1.MugService/GetLed"
+	MugService_SetLed_FullMethodName    = "/muggo.mug.v1.MugService/SetLed"
+	MugService_Watch_FullMethodName     = "/muggo.mug.v1.MugService/Watch"
+)
+
+// MugServiceClient is the client API for MugService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MugService controls a mug.
+type MugServiceClient interface {
+	// GetInfo returns the mug information.
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*Info, error)
+	// GetDevice returns the device information read from the mug.
+	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	GetName(ctx context.Context, in *GetNameRequest, opts ...grpc.CallOption) (*NameResponse, error)
+	SetName(ctx context.Context, in *SetNameRequest, opts ...grpc.CallOption) (*NameResponse, error)
+	GetTarget(ctx context.Context, in *GetTargetRequest, opts ...grpc.CallOption) (*Temperature, error)
+	// SetTarget sets the target temperature and returns the value read back
+	// from the mug.
+	SetTarget(ctx context.Context, in *SetTargetRequest, opts ...grpc.CallOption) (*Temperature, error)
+	GetUnits(ctx context.Context, in *GetUnitsRequest, opts ...grpc.CallOption) (*UnitsResponse, error)
+	SetUnits(ctx context.Context, in *SetUnitsRequest, opts ...grpc.CallOption) (*UnitsResponse, error)
+	GetLed(ctx context.Context, in *GetLedRequest, opts ...grpc.CallOption) (*Color, error)
+	SetLed(ctx context.Context, in *SetLedRequest, opts ...grpc.CallOption) (*Color, error)
+	// Watch streams an Info each time the mug information is updated and a
+	// Connection each time the connection state changes.  The stream starts
+	// with an Info of the current state.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error)
+}
+
+type mugServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMugServiceClient(cc grpc.ClientConnInterface) MugServiceClient {
+	return &mugServiceClient{cc}
+}
+
+func (c *mugServiceClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*Info, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Info)
+	err := c.cc.Invoke(ctx, MugService_GetInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, MugService_GetDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) GetName(ctx context.Context, in *GetNameRequest, opts ...grpc.CallOption) (*NameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NameResponse)
+	err := c.cc.Invoke(ctx, MugService_GetName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) SetName(ctx context.Context, in *SetNameRequest, opts ...grpc.CallOption) (*NameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NameResponse)
+	err := c.cc.Invoke(ctx, MugService_SetName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) GetTarget(ctx context.Context, in *GetTargetRequest, opts ...grpc.CallOption) (*Temperature, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Temperature)
+	err := c.cc.Invoke(ctx, MugService_GetTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) SetTarget(ctx context.Context, in *SetTargetRequest, opts ...grpc.CallOption) (*Temperature, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Temperature)
+	err := c.cc.Invoke(ctx, MugService_SetTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) GetUnits(ctx context.Context, in *GetUnitsRequest, opts ...grpc.CallOption) (*UnitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnitsResponse)
+	err := c.cc.Invoke(ctx, MugService_GetUnits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) SetUnits(ctx context.Context, in *SetUnitsRequest, opts ...grpc.CallOption) (*UnitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnitsResponse)
+	err := c.cc.Invoke(ctx, MugService_SetUnits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) GetLed(ctx context.Context, in *GetLedRequest, opts ...grpc.CallOption) (*Color, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Color)
+	err := c.cc.Invoke(ctx, MugService_GetLed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) SetLed(ctx context.Context, in *SetLedRequest, opts ...grpc.CallOption) (*Color, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Color)
+	err := c.cc.Invoke(ctx, MugService_SetLed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mugServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MugService_ServiceDesc.Streams[0], MugService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MugService_WatchClient = grpc.ServerStreamingClient[WatchResponse]
+
+// MugServiceServer is the server API for MugService service.
+// All implementations must embed UnimplementedMugServiceServer
+// for forward compatibility.
+//
+// MugService controls a mug.
+type MugServiceServer interface {
+	// GetInfo returns the mug information.
+	GetInfo(context.Context, *GetInfoRequest) (*Info, error)
+	// GetDevice returns the device information read from the mug.
+	GetDevice(context.Context, *GetDeviceRequest) (*Device, error)
+	GetName(context.Context, *GetNameRequest) (*NameResponse, error)
+	SetName(context.Context, *SetNameRequest) (*NameResponse, error)
+	GetTarget(context.Context, *GetTargetRequest) (*Temperature, error)
+	// SetTarget sets the target temperature and returns the value read back
+	// from the mug.
+	SetTarget(context.Context, *SetTargetRequest) (*Temperature, error)
+	GetUnits(context.Context, *GetUnitsRequest) (*UnitsResponse, error)
+	SetUnits(context.Context, *SetUnitsRequest) (*UnitsResponse, error)
+	GetLed(context.Context, *GetLedRequest) (*Color, error)
+	SetLed(context.Context, *SetLedRequest) (*Color, error)
+	// Watch streams an Info each time the mug information is updated and a
+	// Connection each time the connection state changes.  The stream starts
+	// with an Info of the current state.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error
+	mustEmbedUnimplementedMugServiceServer()
+}
+
+// UnimplementedMugServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMugServiceServer struct{}
+
+func (UnimplementedMugServiceServer) GetInfo(context.Context, *GetInfoRequest) (*Info, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (UnimplementedMugServiceServer) GetDevice(context.Context, *GetDeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDevice not implemented")
+}
+func (UnimplementedMugServiceServer) GetName(context.Context, *GetNameRequest) (*NameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetName not implemented")
+}
+func (UnimplementedMugServiceServer) SetName(context.Context, *SetNameRequest) (*NameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetName not implemented")
+}
+func (UnimplementedMugServiceServer) GetTarget(context.Context, *GetTargetRequest) (*Temperature, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTarget not implemented")
+}
+func (UnimplementedMugServiceServer) SetTarget(context.Context, *SetTargetRequest) (*Temperature, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTarget not implemented")
+}
+func (UnimplementedMugServiceServer) GetUnits(context.Context, *GetUnitsRequest) (*UnitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnits not implemented")
+}
+func (UnimplementedMugServiceServer) SetUnits(context.Context, *SetUnitsRequest) (*UnitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUnits not implemented")
+}
+func (UnimplementedMugServiceServer) GetLed(context.Context, *GetLedRequest) (*Color, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLed not implemented")
+}
+func (UnimplementedMugServiceServer) SetLed(context.Context, *SetLedRequest) (*Color, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLed not implemented")
+}
+func (UnimplementedMugServiceServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedMugServiceServer) mustEmbedUnimplementedMugServiceServer() {}
+func (UnimplementedMugServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeMugServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MugServiceServer will
+// result in compilation errors.
+type UnsafeMugServiceServer interface {
+	mustEmbedUnimplementedMugServiceServer()
+}
+
+func RegisterMugServiceServer(s grpc.ServiceRegistrar, srv MugServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMugServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MugService_ServiceDesc, srv)
+}
+
+func _MugService_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_GetInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).GetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_GetDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).GetDevice(ctx, req.(*GetDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_GetName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).GetName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_GetName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).GetName(ctx, req.(*GetNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_SetName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).SetName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_SetName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).SetName(ctx, req.(*SetNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_GetTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).GetTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_GetTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).GetTarget(ctx, req.(*GetTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_SetTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).SetTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_SetTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).SetTarget(ctx, req.(*SetTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_GetUnits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).GetUnits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_GetUnits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).GetUnits(ctx, req.(*GetUnitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_SetUnits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUnitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).SetUnits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_SetUnits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).SetUnits(ctx, req.(*SetUnitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_GetLed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).GetLed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_GetLed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).GetLed(ctx, req.(*GetLedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_SetLed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MugServiceServer).SetLed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MugService_SetLed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MugServiceServer).SetLed(ctx, req.(*SetLedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MugService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MugServiceServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MugService_WatchServer = grpc.ServerStreamingServer[WatchResponse]
+
+// MugService_ServiceDesc is the grpc.ServiceDesc for MugService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MugService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "muggo.mug.v1.MugService",
+	HandlerType: (*MugServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _MugService_GetInfo_Handler,
+		},
+		{
+			MethodName: "GetDevice",
+			Handler:    _MugService_GetDevice_Handler,
+		},
+		{
+			MethodName: "GetName",
+			Handler:    _MugService_GetName_Handler,
+		},
+		{
+			MethodName: "SetName",
+			Handler:    _MugService_SetName_Handler,
+		},
+		{
+			MethodName: "GetTarget",
+			Handler:    _MugService_GetTarget_Handler,
+		},
+		{
+			MethodName: "SetTarget",
+			Handler:    _MugService_SetTarget_Handler,
+		},
+		{
+			MethodName: "GetUnits",
+			Handler:    _MugService_GetUnits_Handler,
+		},
+		{
+			MethodName: "SetUnits",
+			Handler:    _MugService_SetUnits_Handler,
+		},
+		{
+			MethodName: "GetLed",
+			Handler:    _MugService_GetLed_Handler,
+		},
+		{
+			MethodName: "SetLed",
+			Handler:    _MugService_SetLed_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _MugService_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mug.proto",
+}
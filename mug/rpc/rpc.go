@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rpc exposes a mug over gRPC.  The service and the generated client
+// are in the mugv1 package:
+//
+//	srv := grpc.NewServer()
+//	mugv1.RegisterMugServiceServer(srv, rpc.NewServer(m))
+//
+// The errors from the mug are returned with the matching status code, see
+// Code.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/schmidtw/muggo/mug/rpc/mugv1"
+	"github.com/schmidtw/muggo/units"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The number of events kept for a Watch that is not keeping up.  The oldest
+// are dropped first.
+const maxPending = 64
+
+// Server serves a mug over gRPC.
+type Server struct {
+	mugv1.UnimplementedMugServiceServer
+
+	m *mug.Mug
+}
+
+var _ mugv1.MugServiceServer = (*Server)(nil)
+
+// NewServer creates a Server for the mug.
+func NewServer(m *mug.Mug) *Server {
+	return &Server{
+		m: m,
+	}
+}
+
+func (s *Server) GetInfo(context.Context, *mugv1.GetInfoRequest) (*mugv1.Info, error) {
+	return newInfo(s.m, s.m.All()), nil
+}
+
+func (s *Server) GetDevice(ctx context.Context, _ *mugv1.GetDeviceRequest) (*mugv1.Device, error) {
+	di, err := s.m.DeviceInfoContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return newDevice(*di), nil
+}
+
+func (s *Server) GetName(ctx context.Context, _ *mugv1.GetNameRequest) (*mugv1.NameResponse, error) {
+	return s.name(ctx)
+}
+
+func (s *Server) SetName(ctx context.Context, req *mugv1.SetNameRequest) (*mugv1.NameResponse, error) {
+	return s.name(ctx, req.GetName())
+}
+
+func (s *Server) name(ctx context.Context, name ...string) (*mugv1.NameResponse, error) {
+	got, err := s.m.NameContext(ctx, name...)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &mugv1.NameResponse{Name: got}, nil
+}
+
+func (s *Server) GetTarget(ctx context.Context, _ *mugv1.GetTargetRequest) (*mugv1.Temperature, error) {
+	return s.target(ctx)
+}
+
+func (s *Server) SetTarget(ctx context.Context, req *mugv1.SetTargetRequest) (*mugv1.Temperature, error) {
+	var temp units.Temperature
+	switch t := req.GetTarget().(type) {
+	case *mugv1.SetTargetRequest_Celsius:
+		temp = units.Temperature(t.Celsius)
+	case *mugv1.SetTargetRequest_Fahrenheit:
+		temp = units.Temperature((t.Fahrenheit - 32) * 5 / 9)
+	case *mugv1.SetTargetRequest_Text:
+		var err error
+		temp, err = units.ParseTemperature(t.Text)
+		if err != nil {
+			return nil, statusError(err)
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "a target is required")
+	}
+
+	if err := mug.CheckTarget(temp); err != nil {
+		return nil, statusError(err)
+	}
+
+	return s.target(ctx, temp)
+}
+
+func (s *Server) target(ctx context.Context, temp ...units.Temperature) (*mugv1.Temperature, error) {
+	got, err := s.m.TargetContext(ctx, temp...)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return newTemperature(got), nil
+}
+
+func (s *Server) GetUnits(ctx context.Context, _ *mugv1.GetUnitsRequest) (*mugv1.UnitsResponse, error) {
+	return s.units(ctx)
+}
+
+func (s *Server) SetUnits(ctx context.Context, req *mugv1.SetUnitsRequest) (*mugv1.UnitsResponse, error) {
+	return s.units(ctx, toUnits(req.GetUnits()))
+}
+
+func (s *Server) units(ctx context.Context, unit ...units.TemperatureUnit) (*mugv1.UnitsResponse, error) {
+	got, err := s.m.UnitsContext(ctx, unit...)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &mugv1.UnitsResponse{Units: newUnits(got)}, nil
+}
+
+func (s *Server) GetLed(ctx context.Context, _ *mugv1.GetLedRequest) (*mugv1.Color, error) {
+	got, err := s.m.LedContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return newColor(*got), nil
+}
+
+func (s *Server) SetLed(ctx context.Context, req *mugv1.SetLedRequest) (*mugv1.Color, error) {
+	c, err := toColor(req.GetColor())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	got, err := s.m.LedContext(ctx, c)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return newColor(*got), nil
+}
+
+func (s *Server) Watch(_ *mugv1.WatchRequest, stream grpc.ServerStreamingServer[mugv1.WatchResponse]) error {
+	ctx := stream.Context()
+	p := pending{
+		ready: make(chan struct{}, 1),
+	}
+
+	cancelInfo := s.m.AddMugListener(mug.MugListenerFunc(func(info mug.MugInfo) {
+		p.add(&mugv1.WatchResponse{
+			Event: &mugv1.WatchResponse_Info{Info: newInfo(s.m, info)},
+		})
+	}))
+	defer cancelInfo()
+
+	cancelState := s.m.AddStateChangeListener(event.StateChangeFunc(func(sc event.StateChange) {
+		p.add(&mugv1.WatchResponse{
+			Event: &mugv1.WatchResponse_Connection{Connection: newConnection(sc)},
+		})
+	}))
+	defer cancelState()
+
+	// The listeners are added first so nothing after the snapshot is missed.
+	err := stream.Send(&mugv1.WatchResponse{
+		Event: &mugv1.WatchResponse_Info{Info: newInfo(s.m, s.m.All())},
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.ready:
+		}
+
+		for _, e := range p.take() {
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// pending holds the events for a Watch so the mug is never blocked by a
+// slow client.
+type pending struct {
+	m      sync.Mutex
+	events []*mugv1.WatchResponse
+	ready  chan struct{}
+}
+
+func (p *pending) add(e *mugv1.WatchResponse) {
+	p.m.Lock()
+	p.events = append(p.events, e)
+	if len(p.events) > maxPending {
+		p.events = p.events[len(p.events)-maxPending:]
+	}
+	p.m.Unlock()
+
+	select {
+	case p.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (p *pending) take() []*mugv1.WatchResponse {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	events := p.events
+	p.events = nil
+	return events
+}
+
+// statusError returns the error from the mug as a gRPC status error.
+func statusError(err error) error {
+	return status.Error(Code(err), err.Error())
+}
+
+// Code returns the gRPC status code for the error from the mug.
+func Code(err error) codes.Code {
+	switch {
+	case err == nil:
+		return codes.OK
+	case errors.Is(err, mug.ErrInvalidInput), errors.Is(err, units.ErrInvalidInput):
+		return codes.InvalidArgument
+	case errors.Is(err, mug.ErrNotSupported):
+		return codes.Unimplemented
+	case errors.Is(err, mug.ErrNotConnected):
+		return codes.Unavailable
+	case errors.Is(err, mug.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, mug.ErrPairingRejected), errors.Is(err, mug.ErrPairingNotSupported):
+		return codes.FailedPrecondition
+	}
+
+	return codes.Unknown
+}
@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/schmidtw/muggo/mug/rpc/mugv1"
+	"github.com/schmidtw/muggo/mug/sim"
+	"github.com/schmidtw/muggo/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// startMug starts a mug connected to a simulator, and returns a client for
+// it.
+func startMug(t *testing.T, opts ...sim.Option) (mugv1.MugServiceClient, *sim.Sim) {
+	t.Helper()
+
+	s, err := sim.New(opts...)
+	require.NoError(t, err)
+
+	connected := make(chan bool, 1)
+	m, err := mug.New(
+		mug.WithTransport(s),
+		mug.WithLogger(nil),
+		mug.WithChangeConnectionListener(
+			event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+				select {
+				case connected <- cc.Connected:
+				default:
+				}
+			})),
+	)
+	require.NoError(t, err)
+
+	m.Start()
+	t.Cleanup(m.Stop)
+
+	select {
+	case got := <-connected:
+		require.True(t, got)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting to connect")
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	mugv1.RegisterMugServiceServer(srv, NewServer(m))
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return mugv1.NewMugServiceClient(conn), s
+}
+
+func TestServer_get(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	client, _ := startMug(t, sim.WithDrink(60), sim.WithTarget(57))
+	ctx := context.Background()
+
+	info, err := client.GetInfo(ctx, &mugv1.GetInfoRequest{})
+	require.NoError(err)
+	assert.Equal("00:00:5E:00:53:01", info.Address)
+	assert.True(info.Connected)
+	assert.Equal(mugv1.ConnectionState_CONNECTION_STATE_CONNECTED, info.Connection)
+	assert.Equal("Ember Sim", info.Name)
+	assert.Equal(60.0, info.Drink.Celsius)
+	assert.Equal(140.0, info.Drink.Fahrenheit)
+	assert.Equal(57.0, info.Target.Celsius)
+	assert.Equal(mugv1.TemperatureUnit_TEMPERATURE_UNIT_CELSIUS, info.Units)
+	assert.Equal(100.0, info.LiquidLevel.Percent)
+	assert.True(proto.Equal(&mugv1.Color{Red: 0xff, Green: 0x7f, Alpha: 0xff}, info.Led))
+	assert.True(info.Capabilities.Led)
+	assert.Equal("SIM0000001", info.Device.Serial)
+
+	device, err := client.GetDevice(ctx, &mugv1.GetDeviceRequest{})
+	require.NoError(err)
+	assert.True(proto.Equal(info.Device, device))
+
+	name, err := client.GetName(ctx, &mugv1.GetNameRequest{})
+	require.NoError(err)
+	assert.Equal("Ember Sim", name.Name)
+
+	target, err := client.GetTarget(ctx, &mugv1.GetTargetRequest{})
+	require.NoError(err)
+	assert.Equal(57.0, target.Celsius)
+
+	unit, err := client.GetUnits(ctx, &mugv1.GetUnitsRequest{})
+	require.NoError(err)
+	assert.Equal(mugv1.TemperatureUnit_TEMPERATURE_UNIT_CELSIUS, unit.Units)
+
+	led, err := client.GetLed(ctx, &mugv1.GetLedRequest{})
+	require.NoError(err)
+	assert.True(proto.Equal(info.Led, led))
+}
+
+func TestServer_set(t *testing.T) {
+	tests := []struct {
+		description string
+		travel      bool
+		call        func(context.Context, mugv1.MugServiceClient) (proto.Message, error)
+		code        codes.Code
+		want        proto.Message
+	}{
+		{
+			description: "target in celsius",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetTarget(ctx, &mugv1.SetTargetRequest{
+					Target: &mugv1.SetTargetRequest_Celsius{Celsius: 56},
+				})
+			},
+			want: &mugv1.Temperature{Celsius: 56, Fahrenheit: 132.8},
+		}, {
+			description: "target in fahrenheit",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetTarget(ctx, &mugv1.SetTargetRequest{
+					Target: &mugv1.SetTargetRequest_Fahrenheit{Fahrenheit: 131},
+				})
+			},
+			want: &mugv1.Temperature{Celsius: 55, Fahrenheit: 131},
+		}, {
+			description: "target as text",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetTarget(ctx, &mugv1.SetTargetRequest{
+					Target: &mugv1.SetTargetRequest_Text{Text: "131F"},
+				})
+			},
+			want: &mugv1.Temperature{Celsius: 55, Fahrenheit: 131},
+		}, {
+			description: "invalid target",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetTarget(ctx, &mugv1.SetTargetRequest{
+					Target: &mugv1.SetTargetRequest_Text{Text: "hot"},
+				})
+			},
+			code: codes.InvalidArgument,
+		}, {
+			description: "target out of range",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetTarget(ctx, &mugv1.SetTargetRequest{
+					Target: &mugv1.SetTargetRequest_Celsius{Celsius: 70},
+				})
+			},
+			code: codes.InvalidArgument,
+		}, {
+			description: "missing target",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetTarget(ctx, &mugv1.SetTargetRequest{})
+			},
+			code: codes.InvalidArgument,
+		}, {
+			description: "units",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetUnits(ctx, &mugv1.SetUnitsRequest{
+					Units: mugv1.TemperatureUnit_TEMPERATURE_UNIT_FAHRENHEIT,
+				})
+			},
+			want: &mugv1.UnitsResponse{Units: mugv1.TemperatureUnit_TEMPERATURE_UNIT_FAHRENHEIT},
+		}, {
+			description: "unspecified units",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetUnits(ctx, &mugv1.SetUnitsRequest{})
+			},
+			code: codes.InvalidArgument,
+		}, {
+			description: "name",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetName(ctx, &mugv1.SetNameRequest{Name: "Coffee"})
+			},
+			want: &mugv1.NameResponse{Name: "Coffee"},
+		}, {
+			description: "led",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetLed(ctx, &mugv1.SetLedRequest{
+					Color: &mugv1.Color{Green: 0xff, Alpha: 0xff},
+				})
+			},
+			want: &mugv1.Color{Green: 0xff, Alpha: 0xff},
+		}, {
+			description: "invalid led",
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetLed(ctx, &mugv1.SetLedRequest{
+					Color: &mugv1.Color{Green: 0x100},
+				})
+			},
+			code: codes.InvalidArgument,
+		}, {
+			description: "the travel mug has no led",
+			travel:      true,
+			call: func(ctx context.Context, c mugv1.MugServiceClient) (proto.Message, error) {
+				return c.SetLed(ctx, &mugv1.SetLedRequest{
+					Color: &mugv1.Color{Green: 0xff, Alpha: 0xff},
+				})
+			},
+			code: codes.Unimplemented,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			model := sim.Ceramic
+			if tc.travel {
+				model = sim.Travel
+			}
+			client, _ := startMug(t, sim.WithModel(model))
+
+			got, err := tc.call(context.Background(), client)
+			assert.Equal(tc.code, status.Code(err), "%v", err)
+			if tc.want != nil {
+				assert.True(proto.Equal(tc.want, got), "%v", got)
+			}
+		})
+	}
+}
+
+func TestServer_Watch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	client, s := startMug(t, sim.WithDrink(50), sim.WithTarget(55))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &mugv1.WatchRequest{})
+	require.NoError(err)
+
+	// The stream starts with the current state.
+	first, err := stream.Recv()
+	require.NoError(err)
+	require.NotNil(first.GetInfo())
+	assert.Equal(50.0, first.GetInfo().Drink.Celsius)
+
+	s.Step(time.Minute)
+	for {
+		e, err := stream.Recv()
+		require.NoError(err)
+		if e.GetInfo().GetDrink().GetCelsius() == 53 {
+			break
+		}
+	}
+
+	s.Disconnect()
+	for {
+		e, err := stream.Recv()
+		require.NoError(err)
+		if c := e.GetConnection(); c != nil {
+			assert.Equal("00:00:5E:00:53:01", c.Address)
+			assert.Equal(mugv1.ConnectionState_CONNECTION_STATE_CONNECTED, c.Previous)
+			assert.NotEqual(mugv1.ConnectionState_CONNECTION_STATE_CONNECTED, c.State)
+			break
+		}
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{err: nil, want: codes.OK},
+		{err: mug.ErrInvalidInput, want: codes.InvalidArgument},
+		{err: units.ErrInvalidInput, want: codes.InvalidArgument},
+		{err: mug.ErrNotSupported, want: codes.Unimplemented},
+		{err: fmt.Errorf("wrapped: %w", mug.ErrNotConnected), want: codes.Unavailable},
+		{err: mug.ErrTimeout, want: codes.DeadlineExceeded},
+		{err: context.Canceled, want: codes.Canceled},
+		{err: mug.ErrPairingRejected, want: codes.FailedPrecondition},
+		{err: errors.New("bluetooth"), want: codes.Unknown},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, Code(tc.err), "%v", tc.err)
+	}
+}
+
+func TestNewConnection(t *testing.T) {
+	tests := []struct {
+		state event.ConnectionState
+		want  mugv1.ConnectionState
+	}{
+		{state: event.Disconnected, want: mugv1.ConnectionState_CONNECTION_STATE_DISCONNECTED},
+		{state: event.Connected, want: mugv1.ConnectionState_CONNECTION_STATE_CONNECTED},
+		{state: event.ConnectionState(99), want: mugv1.ConnectionState_CONNECTION_STATE_UNSPECIFIED},
+	}
+	for _, tc := range tests {
+		got := newConnection(event.StateChange{State: tc.state})
+		assert.Equal(t, tc.want, got.State, "%v", tc.state)
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/schmidtw/muggo/mug/rpc/mugv1"
+	"github.com/schmidtw/muggo/units"
+)
+
+func newTemperature(t units.Temperature) *mugv1.Temperature {
+	return &mugv1.Temperature{
+		Celsius:    t.C(),
+		Fahrenheit: t.F(),
+	}
+}
+
+func newColor(c color.NRGBA) *mugv1.Color {
+	return &mugv1.Color{
+		Red:   uint32(c.R),
+		Green: uint32(c.G),
+		Blue:  uint32(c.B),
+		Alpha: uint32(c.A),
+	}
+}
+
+// toColor converts the color, which must have 8 bit channels.
+func toColor(c *mugv1.Color) (color.NRGBA, error) {
+	if c == nil {
+		return color.NRGBA{}, fmt.Errorf("%w: a color is required", mug.ErrInvalidInput)
+	}
+	for _, v := range []uint32{c.Red, c.Green, c.Blue, c.Alpha} {
+		if v > 0xff {
+			return color.NRGBA{}, fmt.Errorf("%w: %d is not a color channel", mug.ErrInvalidInput, v)
+		}
+	}
+
+	return color.NRGBA{
+		R: uint8(c.Red),
+		G: uint8(c.Green),
+		B: uint8(c.Blue),
+		A: uint8(c.Alpha),
+	}, nil
+}
+
+func newUnits(u units.TemperatureUnit) mugv1.TemperatureUnit {
+	switch u {
+	case units.Celsius:
+		return mugv1.TemperatureUnit_TEMPERATURE_UNIT_CELSIUS
+	case units.Fahrenheit:
+		return mugv1.TemperatureUnit_TEMPERATURE_UNIT_FAHRENHEIT
+	}
+
+	return mugv1.TemperatureUnit_TEMPERATURE_UNIT_UNSPECIFIED
+}
+
+func toUnits(u mugv1.TemperatureUnit) units.TemperatureUnit {
+	switch u {
+	case mugv1.TemperatureUnit_TEMPERATURE_UNIT_CELSIUS:
+		return units.Celsius
+	case mugv1.TemperatureUnit_TEMPERATURE_UNIT_FAHRENHEIT:
+		return units.Fahrenheit
+	}
+
+	return units.Unknown
+}
+
+var stateMap = map[mug.State]mugv1.State{
+	mug.Empty:   mugv1.State_STATE_EMPTY,
+	mug.Filling: mugv1.State_STATE_FILLING,
+	mug.Cold:    mugv1.State_STATE_COLD,
+	mug.Cooling: mugv1.State_STATE_COOLING,
+	mug.Heating: mugv1.State_STATE_HEATING,
+	mug.Perfect: mugv1.State_STATE_PERFECT,
+	mug.Hot:     mugv1.State_STATE_HOT,
+}
+
+var connectionStateMap = map[event.ConnectionState]mugv1.ConnectionState{
+	event.Disconnected:        mugv1.ConnectionState_CONNECTION_STATE_DISCONNECTED,
+	event.AdapterDisabled:     mugv1.ConnectionState_CONNECTION_STATE_ADAPTER_DISABLED,
+	event.Scanning:            mugv1.ConnectionState_CONNECTION_STATE_SCANNING,
+	event.Connecting:          mugv1.ConnectionState_CONNECTION_STATE_CONNECTING,
+	event.DiscoveringServices: mugv1.ConnectionState_CONNECTION_STATE_DISCOVERING_SERVICES,
+	event.Connected:           mugv1.ConnectionState_CONNECTION_STATE_CONNECTED,
+	event.Backoff:             mugv1.ConnectionState_CONNECTION_STATE_BACKOFF,
+}
+
+func newDevice(di mug.DeviceInfo) *mugv1.Device {
+	return &mugv1.Device{
+		Firmware:   di.Firmware().String(),
+		Hardware:   uint32(di.HardwareVersion),
+		Bootloader: di.Bootloader().String(),
+		Serial:     di.SerialNumber,
+		MugId:      di.MugID.String(),
+	}
+}
+
+func newInfo(m *mug.Mug, info mug.MugInfo) *mugv1.Info {
+	caps := info.Model.Capabilities()
+
+	return &mugv1.Info{
+		Address:    m.Address().String(),
+		Connected:  m.IsConnected(),
+		Connection: connectionStateMap[m.ConnectionState()],
+		Name:       info.Name,
+		Drink:      newTemperature(info.Drink),
+		Target:     newTemperature(info.Target),
+		Units:      newUnits(info.Units),
+		State:      stateMap[info.State],
+		Battery: &mugv1.Battery{
+			Percent:     info.Battery.PercentLeft,
+			Charging:    info.Battery.Charging,
+			Temperature: newTemperature(info.Battery.Temp),
+		},
+		LiquidLevel: &mugv1.LiquidLevel{
+			Raw:     uint32(info.LiquidLevel.Raw),
+			Percent: info.LiquidLevel.Percent,
+			Empty:   info.LiquidLevel.IsEmpty(),
+		},
+		VolumeMl: info.Volume.ML(),
+		Led:      newColor(info.LED),
+		Model:    info.Model.String(),
+		Capabilities: &mugv1.Capabilities{
+			DisplayUnits:  caps.DisplayUnits,
+			Volume:        caps.Volume,
+			Led:           caps.LED,
+			TravelDisplay: caps.TravelDisplay,
+		},
+		Device: newDevice(info.DeviceInfo),
+	}
+}
+
+func newConnection(sc event.StateChange) *mugv1.Connection {
+	c := mugv1.Connection{
+		Address:        sc.Address.String(),
+		State:          connectionStateMap[sc.State],
+		Previous:       connectionStateMap[sc.Previous],
+		Attempt:        int32(sc.Attempt),
+		RetryInSeconds: sc.Delay.Seconds(),
+	}
+	if sc.Err != nil {
+		c.Error = sc.Err.Error()
+	}
+
+	return &c
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package sim
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleet(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	a, err := New(WithAddress("00:00:5E:00:53:01"), WithName("Mug A"))
+	require.NoError(err)
+	b, err := New(WithAddress("00:00:5E:00:53:02"), WithName("Mug B"))
+	require.NoError(err)
+
+	f, err := mug.NewFleet(
+		mug.WithTransport(NewTransport(a, b)),
+		mug.WithLogger(nil),
+	)
+	require.NoError(err)
+
+	changes := make(chan event.FleetChange, 10)
+	f.AddFleetChangeListener(event.FleetChangeFunc(func(fc event.FleetChange) {
+		changes <- fc
+	}))
+	next := func() event.FleetChange {
+		t.Helper()
+		select {
+		case fc := <-changes:
+			return fc
+		case <-time.After(time.Second):
+			require.FailNow("timed out waiting for a fleet change")
+		}
+		return event.FleetChange{}
+	}
+
+	f.Start()
+	t.Cleanup(f.Stop)
+
+	// Both mugs are added once they are connected.
+	added := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		fc := next()
+		assert.True(fc.Added)
+		added[fc.Address.String()] = true
+
+		m := f.Mug(fc.Address)
+		require.NotNil(m)
+		assert.True(m.IsConnected())
+	}
+	assert.Equal(map[string]bool{"00:00:5E:00:53:01": true, "00:00:5E:00:53:02": true}, added)
+	require.Len(f.Mugs(), 2)
+
+	ma := f.Mug(a.address)
+	mb := f.Mug(b.address)
+	assert.Equal("Mug A", ma.All().Name)
+	assert.Equal("Mug B", mb.All().Name)
+
+	// A mug that drops out reconnects as the same mug.
+	a.Disconnect()
+	assert.Eventually(func() bool {
+		return a.IsConnected() && ma.IsConnected()
+	}, time.Second, time.Millisecond)
+	assert.Same(ma, f.Mug(a.address))
+	assert.Empty(changes)
+
+	// Removing a mug drops its connection.  It is still in range, so it is
+	// added back as a new mug.
+	f.Remove(b.address)
+	fc := next()
+	assert.Equal(event.FleetChange{Address: b.address, Added: false}, fc)
+	assert.False(mb.IsConnected())
+
+	fc = next()
+	assert.Equal(event.FleetChange{Address: b.address, Added: true}, fc)
+	assert.NotSame(mb, f.Mug(b.address))
+	assert.True(f.Mug(b.address).IsConnected())
+
+	// Stopping the fleet drops all of the connections.
+	f.Stop()
+	assert.Eventually(func() bool {
+		return !a.IsConnected() && !b.IsConnected()
+	}, time.Second, time.Millisecond)
+	assert.Len(f.Mugs(), 2)
+}
+
+func TestFleet_listeners(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	a, err := New(WithAddress("00:00:5E:00:53:01"))
+	require.NoError(err)
+	b, err := New(WithAddress("00:00:5E:00:53:02"))
+	require.NoError(err)
+
+	// A cancel function could only cancel the listener of one mug.
+	var cancelOpt mug.CancelEventListenerFunc
+	_, err = mug.NewFleet(
+		mug.WithTransport(NewTransport(a, b)),
+		mug.WithChangeConnectionListener(event.ConnectionChangeFunc(func(event.ConnectionChange) {}), &cancelOpt),
+	)
+	assert.ErrorIs(err, mug.ErrInvalidInput)
+
+	f, err := mug.NewFleet(
+		mug.WithTransport(NewTransport(a, b)),
+		mug.WithLogger(nil),
+	)
+	require.NoError(err)
+
+	var m sync.Mutex
+	connects := map[string]int{}
+	states := map[string]int{}
+	cancelConn := f.AddConnectionChangeListener(event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+		m.Lock()
+		defer m.Unlock()
+		if cc.Connected {
+			connects[cc.Address.String()]++
+		}
+	}))
+	cancelState := f.AddStateChangeListener(event.StateChangeFunc(func(sc event.StateChange) {
+		m.Lock()
+		defer m.Unlock()
+		states[sc.Address.String()]++
+	}))
+
+	f.Start()
+	t.Cleanup(f.Stop)
+
+	// Both mugs report to the fleet listeners.
+	assert.Eventually(func() bool {
+		m.Lock()
+		defer m.Unlock()
+		return connects["00:00:5E:00:53:01"] == 1 && connects["00:00:5E:00:53:02"] == 1 &&
+			states["00:00:5E:00:53:01"] > 0 && states["00:00:5E:00:53:02"] > 0
+	}, time.Second, time.Millisecond)
+
+	// Once cancelled, neither mug reports to the listeners.
+	cancelConn()
+	cancelState()
+
+	m.Lock()
+	want := map[string]int{}
+	for addr, n := range states {
+		want[addr] = n
+	}
+	m.Unlock()
+
+	ma := f.Mug(a.address)
+	mb := f.Mug(b.address)
+	a.Disconnect()
+	b.Disconnect()
+	assert.Eventually(func() bool {
+		return a.IsConnected() && ma.IsConnected() && b.IsConnected() && mb.IsConnected()
+	}, time.Second, time.Millisecond)
+
+	m.Lock()
+	defer m.Unlock()
+	assert.Equal(map[string]int{"00:00:5E:00:53:01": 1, "00:00:5E:00:53:02": 1}, connects)
+	assert.Equal(want, states)
+}
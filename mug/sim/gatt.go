@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package sim
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/units"
+	bt "tinygo.org/x/bluetooth"
+)
+
+// The characteristic ids, these match the numbering used by the mug package.
+const (
+	apiName         = 1
+	apiDrink        = 2
+	apiTarget       = 3
+	apiUnits        = 4
+	apiLiquidLevel  = 5
+	apiTimeDateZone = 6
+	apiBattery      = 7
+	apiState        = 8
+	apiFirmwareInfo = 12
+	apiID           = 13
+	apiKey0         = 14
+	apiKey1         = 15
+	apiPushEvent    = 18
+	apiLED          = 20
+)
+
+// The liquid states reported by the mug.
+const (
+	stateEmpty   = 1
+	stateFilling = 2
+	stateCold    = 3
+	stateCooling = 4
+	stateHeating = 5
+	statePerfect = 6
+	stateHot     = 7
+)
+
+const mtu = 20
+
+var (
+	ErrNotConnected  = mug.ErrNotConnected
+	ErrNotWritable   = errors.New("characteristic is not writable")
+	ErrNotNotifiable = errors.New("characteristic does not notify")
+	ErrNotPaired     = errors.New("mug is not paired")
+)
+
+// characteristic is a single simulated GATT characteristic.
+type characteristic struct {
+	sim  *Sim
+	api  int
+	uuid bt.UUID
+}
+
+var _ mug.Characteristic = (*characteristic)(nil)
+
+// characteristics returns the characteristics the model supports.  The lock
+// must be held.
+func (s *Sim) characteristics() []mug.Characteristic {
+	apis := []int{
+		apiName,
+		apiDrink,
+		apiTarget,
+		apiUnits,
+		apiLiquidLevel,
+		apiTimeDateZone,
+		apiBattery,
+		apiState,
+		apiFirmwareInfo,
+		apiID,
+		apiPushEvent,
+	}
+
+	// Only the ceramic mug has a LED and only the travel mug pairs.
+	switch s.model {
+	case Ceramic:
+		apis = append(apis, apiLED)
+	case Travel:
+		apis = append(apis, apiKey0, apiKey1)
+	}
+
+	rv := make([]mug.Characteristic, 0, len(apis))
+	for _, api := range apis {
+		rv = append(rv, &characteristic{
+			sim:  s,
+			api:  api,
+			uuid: mug.CharacteristicUUID(api),
+		})
+	}
+
+	return rv
+}
+
+// services returns the services the model provides.  The lock must be held.
+func (s *Sim) services() []bt.UUID {
+	service := mug.EmberCeramicMugMainServiceUUID
+	if s.model == Travel {
+		service = mug.EmberTravelMugMainServiceUUID
+	}
+
+	// The service UUIDs are constants, so they always parse.
+	uuid, _ := bt.ParseUUID(service)
+	return []bt.UUID{uuid}
+}
+
+func (c *characteristic) UUID() bt.UUID {
+	return c.uuid
+}
+
+func (c *characteristic) GetMTU() (uint16, error) {
+	return mtu, nil
+}
+
+func (c *characteristic) Read(data []byte) (int, error) {
+	c.sim.m.Lock()
+	defer c.sim.m.Unlock()
+
+	if !c.sim.connected {
+		return 0, ErrNotConnected
+	}
+
+	return copy(data, c.sim.value(c.api)), nil
+}
+
+func (c *characteristic) WriteWithoutResponse(p []byte) (int, error) {
+	c.sim.m.Lock()
+	if !c.sim.connected {
+		c.sim.m.Unlock()
+		return 0, ErrNotConnected
+	}
+
+	events, err := c.sim.setValue(c.api, p)
+	c.sim.m.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	c.sim.send(events...)
+
+	return len(p), nil
+}
+
+func (c *characteristic) EnableNotifications(callback func(buf []byte)) error {
+	if c.api != apiPushEvent {
+		return ErrNotNotifiable
+	}
+
+	c.sim.m.Lock()
+	defer c.sim.m.Unlock()
+
+	c.sim.notify = callback
+	return nil
+}
+
+// value returns the encoded value of the characteristic.  The lock must be
+// held.
+func (s *Sim) value(api int) []byte {
+	switch api {
+	case apiName:
+		return []byte(s.name)
+	case apiDrink:
+		return s.drink.ToMug()
+	case apiTarget:
+		return s.target.ToMug()
+	case apiUnits:
+		if s.unit == units.Fahrenheit {
+			return []byte{1, 0}
+		}
+		return []byte{0, 0}
+	case apiLiquidLevel:
+		return []byte{s.level}
+	case apiTimeDateZone:
+		return append([]byte{}, s.clock...)
+	case apiBattery:
+		charging := byte(0)
+		if s.onCoast {
+			charging = 1
+		}
+		buf := []byte{byte(s.battery), charging, 0, 0, 0}
+		// The battery is kept a few degrees below the drink.
+		copy(buf[2:4], (s.drink - 5).ToMug())
+		return buf
+	case apiState:
+		return []byte{s.state}
+	case apiFirmwareInfo:
+		buf := make([]byte, 6)
+		binary.LittleEndian.PutUint16(buf[0:2], s.firmware)
+		binary.LittleEndian.PutUint16(buf[2:4], s.hardware)
+		binary.LittleEndian.PutUint16(buf[4:6], s.boot)
+		return buf
+	case apiID:
+		// The first 6 bytes are the mug id, then the serial number.
+		return append([]byte{0, 0, 0, 0, 0, 0}, []byte(s.serial)...)
+	case apiLED:
+		return []byte{s.led.R, s.led.G, s.led.B, s.led.A}
+	case apiKey0:
+		return append([]byte{}, s.udsk...)
+	case apiKey1:
+		return append([]byte{}, s.dsk...)
+	}
+
+	return nil
+}
+
+// setValue decodes and applies a write to the characteristic, returning any
+// push events that need to be sent.  The lock must be held.
+func (s *Sim) setValue(api int, p []byte) ([]byte, error) {
+	// The simulated travel mug only accepts writes once paired.
+	if s.model == Travel && !s.paired && api != apiKey0 {
+		return nil, ErrNotPaired
+	}
+
+	switch api {
+	case apiName:
+		s.name = string(p)
+		return nil, nil
+	case apiTarget:
+		if len(p) != 2 {
+			return nil, ErrInvalidInput
+		}
+		s.target = units.FromMug(p)
+		return append([]byte{mug.NOTIFY_TARGET_CHANGED}, s.updateState()...), nil
+	case apiUnits:
+		if len(p) < 1 || 1 < p[0] {
+			return nil, ErrInvalidInput
+		}
+		s.unit = units.Celsius
+		if p[0] == 1 {
+			s.unit = units.Fahrenheit
+		}
+		return nil, nil
+	case apiTimeDateZone:
+		if len(p) != 5 {
+			return nil, ErrInvalidInput
+		}
+		s.clock = append([]byte{}, p...)
+		return nil, nil
+	case apiLED:
+		if len(p) != 4 {
+			return nil, ErrInvalidInput
+		}
+		s.led.R, s.led.G, s.led.B, s.led.A = p[0], p[1], p[2], p[3]
+		return nil, nil
+	case apiKey0:
+		// The first key written is remembered, after that only the same
+		// key pairs.  This is a guess at how the mug behaves that matches
+		// mug.Pair, it has not been checked against a real mug.
+		if len(s.udsk) == 0 {
+			s.udsk = append([]byte{}, p...)
+		}
+		s.paired = string(s.udsk) == string(p)
+		return nil, nil
+	}
+
+	return nil, ErrNotWritable
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package sim
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/units"
+	bt "tinygo.org/x/bluetooth"
+)
+
+var (
+	ErrInvalidInput = mug.ErrInvalidInput
+)
+
+type Option interface {
+	apply(*Sim) error
+}
+
+type OptionFunc func(*Sim) error
+
+func (f OptionFunc) apply(s *Sim) error {
+	return f(s)
+}
+
+// WithAddress sets the address the simulated mug reports.
+func WithAddress(mac string) Option {
+	return OptionFunc(func(s *Sim) error {
+		macAddr, err := bt.ParseMAC(mac)
+		if err != nil {
+			return errors.Join(ErrInvalidInput, err)
+		}
+		s.address = bt.Address{
+			MACAddress: bt.MACAddress{MAC: macAddr},
+		}
+		return nil
+	})
+}
+
+// WithModel sets the kind of mug to simulate.  The model determines the
+// hardware version reported and which characteristics are available.
+func WithModel(model Model) Option {
+	return OptionFunc(func(s *Sim) error {
+		switch model {
+		case Ceramic:
+			s.firmware, s.hardware, s.boot = 0x0252, 0x0002, 0x0006
+		case Travel:
+			s.firmware, s.hardware, s.boot = 0x0144, 0x0004, 0x0006
+		default:
+			return ErrInvalidInput
+		}
+		s.model = model
+		return nil
+	})
+}
+
+// WithName sets the name of the mug.
+func WithName(name string) Option {
+	return OptionFunc(func(s *Sim) error {
+		s.name = name
+		return nil
+	})
+}
+
+// WithSerial sets the serial number of the mug.
+func WithSerial(serial string) Option {
+	return OptionFunc(func(s *Sim) error {
+		s.serial = serial
+		return nil
+	})
+}
+
+// WithAmbient sets the temperature of the room the mug is in.
+func WithAmbient(temp units.Temperature) Option {
+	return OptionFunc(func(s *Sim) error {
+		s.ambient = temp
+		return nil
+	})
+}
+
+// WithDrink sets the starting temperature of the drink.
+func WithDrink(temp units.Temperature) Option {
+	return OptionFunc(func(s *Sim) error {
+		s.drink = temp
+		return nil
+	})
+}
+
+// WithTarget sets the target temperature of the mug.
+func WithTarget(temp units.Temperature) Option {
+	return OptionFunc(func(s *Sim) error {
+		s.target = temp
+		return nil
+	})
+}
+
+// WithUnits sets the units the mug displays.
+func WithUnits(unit units.TemperatureUnit) Option {
+	return OptionFunc(func(s *Sim) error {
+		if unit != units.Celsius && unit != units.Fahrenheit {
+			return ErrInvalidInput
+		}
+		s.unit = unit
+		return nil
+	})
+}
+
+// WithLiquidLevel sets the starting liquid level from 0 to FullLevel.
+func WithLiquidLevel(level byte) Option {
+	return OptionFunc(func(s *Sim) error {
+		if level > FullLevel {
+			return ErrInvalidInput
+		}
+		s.level = level
+		return nil
+	})
+}
+
+// WithBattery sets the starting battery level in percent.
+func WithBattery(percent float64) Option {
+	return OptionFunc(func(s *Sim) error {
+		if percent < 0 || 100 < percent {
+			return ErrInvalidInput
+		}
+		s.battery = percent
+		return nil
+	})
+}
+
+// OnCoaster sets if the mug starts on its charging coaster.
+func OnCoaster(on bool) Option {
+	return OptionFunc(func(s *Sim) error {
+		s.onCoast = on
+		return nil
+	})
+}
+
+// WithLED sets the color of the mug LED.
+func WithLED(c color.NRGBA) Option {
+	return OptionFunc(func(s *Sim) error {
+		s.led = c
+		return nil
+	})
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package sim
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startMug starts a mug connected to the simulator and returns a function
+// that waits for the next connection change.
+func startMug(t *testing.T, s *Sim, opts ...mug.Option) (*mug.Mug, func() bool) {
+	t.Helper()
+
+	connected := make(chan bool, 10)
+	opts = append(opts,
+		mug.WithTransport(s),
+		mug.WithLogger(nil),
+		mug.WithChangeConnectionListener(
+			event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+				connected <- cc.Connected
+			})),
+	)
+	m, err := mug.New(opts...)
+	require.NoError(t, err)
+
+	m.Start()
+	t.Cleanup(m.Stop)
+
+	return m, func() bool {
+		t.Helper()
+		select {
+		case got := <-connected:
+			return got
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for a connection change")
+		}
+		return false
+	}
+}
+
+func TestPair(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	s, err := New(WithModel(Travel))
+	require.NoError(err)
+
+	keys := mug.NewFileKeyStore(filepath.Join(t.TempDir(), "keys.json"))
+	m, next := startMug(t, s, mug.WithKeyStore(keys))
+	require.True(next())
+
+	// Writes are refused until the mug is paired.
+	_, err = m.TargetContext(ctx, 50)
+	assert.ErrorIs(err, ErrNotPaired)
+
+	require.NoError(m.Pair(ctx))
+	_, err = m.TargetContext(ctx, 50)
+	assert.NoError(err)
+
+	// The key is reused when the mug reconnects.
+	s.Disconnect()
+	require.False(next())
+	require.True(next())
+	_, err = m.TargetContext(ctx, 51)
+	assert.NoError(err)
+
+	// Pairing again with the same key works.
+	assert.NoError(m.Pair(ctx))
+
+	// Another client with a different key is rejected.
+	other, nextOther := startMug(t, s)
+	m.Stop()
+	s.Disconnect()
+	require.True(nextOther())
+
+	err = other.Pair(ctx)
+	var pe *mug.PairingError
+	require.True(errors.As(err, &pe))
+	assert.ErrorIs(err, mug.ErrPairingRejected)
+	assert.Equal(s.address, pe.Address)
+	other.Stop()
+	s.Disconnect()
+
+	// After a reset the saved key is not used and pairing starts over.
+	s.ResetPairing()
+	m, next = startMug(t, s, mug.WithKeyStore(keys))
+	require.True(next())
+	_, err = m.TargetContext(ctx, 52)
+	assert.ErrorIs(err, ErrNotPaired)
+	assert.NoError(m.Pair(ctx))
+	_, err = m.TargetContext(ctx, 52)
+	assert.NoError(err)
+}
+
+func TestPair_NotSupported(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(WithModel(Ceramic))
+	require.NoError(err)
+
+	m, err := mug.New(mug.WithTransport(s), mug.WithLogger(nil))
+	require.NoError(err)
+
+	// Not connected yet.
+	err = m.Pair(context.Background())
+	require.ErrorIs(err, mug.ErrNotConnected)
+
+	m, next := startMug(t, s)
+	require.True(next())
+
+	err = m.Pair(context.Background())
+	var pe *mug.PairingError
+	require.True(errors.As(err, &pe))
+	require.ErrorIs(err, mug.ErrPairingNotSupported)
+}
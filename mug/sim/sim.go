@@ -0,0 +1,371 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sim provides an in-process Ember mug simulator that works at the
+// GATT level.  A *Sim satisfies the mug.Transport interface, so a *mug.Mug can
+// be driven without any bluetooth hardware.
+package sim
+
+import (
+	"context"
+	"crypto/sha256"
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/units"
+	bt "tinygo.org/x/bluetooth"
+)
+
+// Model is the kind of mug being simulated.
+type Model int
+
+const (
+	Ceramic Model = iota
+	Travel
+)
+
+const (
+	// The liquid level reported by the mug ranges from 0 (empty) to 30 (full).
+	FullLevel = mug.FullLiquidLevel
+
+	// How quickly the heater warms the drink in °C per second.
+	heatRate = 0.05
+
+	// How quickly the drink approaches the ambient temperature per second
+	// when it is not being heated (Newton's law of cooling).
+	coolRate = 1.0 / 1800
+
+	// Battery change rates in percent per second.
+	drainRate  = 100.0 / (80 * 60)
+	chargeRate = 100.0 / (120 * 60)
+
+	// The drink temperature must move this far before a push event is sent.
+	drinkNotifyDelta = 0.5
+
+	// How close to the target the drink must be to be considered perfect.
+	perfectDelta = 0.5
+)
+
+// Sim is a simulated mug.
+type Sim struct {
+	m sync.Mutex
+
+	address bt.Address
+	model   Model
+
+	name     string
+	drink    units.Temperature
+	target   units.Temperature
+	ambient  units.Temperature
+	unit     units.TemperatureUnit
+	level    byte
+	battery  float64
+	onCoast  bool
+	state    byte
+	led      color.NRGBA
+	clock    []byte
+	firmware uint16
+	hardware uint16
+	boot     uint16
+	serial   string
+
+	// The keys used for pairing the travel mug.
+	dsk    []byte
+	udsk   []byte
+	paired bool
+
+	notified units.Temperature
+	notify   func([]byte)
+
+	connected    bool
+	disconnected chan struct{}
+}
+
+var _ mug.Transport = (*Sim)(nil)
+
+// New creates a new simulated mug that is full of hot coffee and sitting on
+// its coaster.
+func New(opts ...Option) (*Sim, error) {
+	s := Sim{
+		clock: make([]byte, 5),
+	}
+
+	all := append([]Option{
+		WithAddress("00:00:5E:00:53:01"),
+		WithModel(Ceramic),
+		WithName("Ember Sim"),
+		WithAmbient(21),
+		WithDrink(60),
+		WithTarget(57),
+		WithUnits(units.Celsius),
+		WithLiquidLevel(FullLevel),
+		WithBattery(80),
+		OnCoaster(true),
+		WithLED(color.NRGBA{R: 0xff, G: 0x7f, B: 0x00, A: 0xff}),
+		WithSerial("SIM-0000001"),
+	}, opts...)
+
+	for _, opt := range all {
+		if opt != nil {
+			if err := opt.apply(&s); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s.notified = s.drink
+	s.state = s.calcState()
+
+	// The mug's own key is derived from the serial so it is stable.
+	dsk := sha256.Sum256([]byte(s.serial))
+	s.dsk = dsk[:mtu]
+
+	return &s, nil
+}
+
+// Connect connects to the simulated mug.  If the mug is already connected,
+// Connect blocks until the mug is disconnected or the context is canceled.
+func (s *Sim) Connect(ctx context.Context) (*mug.Connection, error) {
+	for {
+		s.m.Lock()
+		if !s.connected {
+			s.connected = true
+			s.paired = false
+			disconnected := make(chan struct{})
+			s.disconnected = disconnected
+			conn := mug.Connection{
+				Address:         s.address,
+				ServiceUUIDs:    s.services(),
+				Characteristics: s.characteristics(),
+				Disconnected:    disconnected,
+				Disconnect: func() error {
+					s.drop(disconnected)
+					return nil
+				},
+			}
+			s.m.Unlock()
+			return &conn, nil
+		}
+		disconnected := s.disconnected
+		s.m.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-disconnected:
+		}
+	}
+}
+
+// Disconnect drops the connection to the mug, as if it moved out of range.
+func (s *Sim) Disconnect() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.disconnect()
+}
+
+// drop drops the connection if it is still the current one.
+func (s *Sim) drop(disconnected chan struct{}) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.disconnected == disconnected {
+		s.disconnect()
+	}
+}
+
+func (s *Sim) disconnect() {
+	if !s.connected {
+		return
+	}
+
+	s.connected = false
+	s.notify = nil
+	close(s.disconnected)
+}
+
+// ResetPairing forgets the key the mug was paired with and changes the mug's
+// own key, as if the mug was factory reset.
+func (s *Sim) ResetPairing() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	dsk := sha256.Sum256(s.dsk)
+	s.dsk = dsk[:mtu]
+	s.udsk = nil
+	s.paired = false
+}
+
+// IsConnected returns if a client is connected to the simulated mug.
+func (s *Sim) IsConnected() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.connected
+}
+
+// Run advances the simulation in real time until the context is canceled.
+func (s *Sim) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Step(interval)
+		}
+	}
+}
+
+// Step advances the simulation by the specified duration and sends any
+// resulting push events.
+func (s *Sim) Step(d time.Duration) {
+	s.m.Lock()
+	events := s.step(d.Seconds())
+	s.m.Unlock()
+
+	s.send(events...)
+}
+
+// Fill sets the liquid level of the mug, clamped to FullLevel.
+func (s *Sim) Fill(level byte) {
+	s.m.Lock()
+	s.level = min(level, FullLevel)
+	events := []byte{mug.NOTIFY_LIQUID_LEVEL_CHANGED}
+	events = append(events, s.updateState()...)
+	s.m.Unlock()
+
+	s.send(events...)
+}
+
+// Pour sets the drink temperature, as if a fresh drink was poured.
+func (s *Sim) Pour(temp units.Temperature) {
+	s.m.Lock()
+	s.drink = temp
+	s.notified = temp
+	events := []byte{mug.NOTIFY_DRINK_CHANGED}
+	events = append(events, s.updateState()...)
+	s.m.Unlock()
+
+	s.send(events...)
+}
+
+// PlaceOnCoaster puts the mug on (true) or lifts it off (false) the charging
+// coaster.
+func (s *Sim) PlaceOnCoaster(on bool) {
+	s.m.Lock()
+	if s.onCoast == on {
+		s.m.Unlock()
+		return
+	}
+	s.onCoast = on
+
+	events := []byte{mug.NOTIFY_DISCHARGING}
+	if on {
+		events[0] = mug.NOTIFY_CHARGHING
+	}
+	events = append(events, s.updateState()...)
+	s.m.Unlock()
+
+	s.send(events...)
+}
+
+// Drink returns the simulated drink temperature.
+func (s *Sim) Drink() units.Temperature {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.drink
+}
+
+// Battery returns the simulated battery level in percent.
+func (s *Sim) Battery() float64 {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.battery
+}
+
+// step runs the thermal and battery model and returns the push events that
+// need to be sent.  The lock must be held.
+func (s *Sim) step(dt float64) []byte {
+	var events []byte
+
+	// The heater only runs on the coaster, the drink cools once it is lifted
+	// off.
+	powered := s.level > 0 && s.onCoast
+
+	if powered && s.drink < s.target {
+		s.drink = min(s.drink+units.Temperature(heatRate*dt), s.target)
+	} else {
+		s.drink += units.Temperature(float64(s.ambient-s.drink) * (1 - math.Exp(-coolRate*dt)))
+		if powered && s.drink < s.target {
+			s.drink = s.target
+		}
+	}
+
+	if math.Abs(float64(s.drink-s.notified)) >= drinkNotifyDelta {
+		s.notified = s.drink
+		events = append(events, mug.NOTIFY_DRINK_CHANGED)
+	}
+
+	before := math.Floor(s.battery)
+	if s.onCoast {
+		s.battery = math.Min(100, s.battery+chargeRate*dt)
+	} else if s.level > 0 {
+		s.battery = math.Max(0, s.battery-drainRate*dt)
+	}
+	if math.Floor(s.battery) != before {
+		events = append(events, mug.NOTIFY_BATTERY)
+	}
+
+	return append(events, s.updateState()...)
+}
+
+// updateState recalculates the state and returns a push event if it changed.
+// The lock must be held.
+func (s *Sim) updateState() []byte {
+	state := s.calcState()
+	if state == s.state {
+		return nil
+	}
+
+	s.state = state
+	return []byte{mug.NOTIFY_STATE_CHANGED}
+}
+
+func (s *Sim) calcState() byte {
+	switch {
+	case s.level == 0:
+		return stateEmpty
+	case math.Abs(float64(s.drink-s.target)) <= perfectDelta:
+		return statePerfect
+	case s.drink > s.target:
+		return stateCooling
+	case s.onCoast:
+		return stateHeating
+	}
+
+	return stateCold
+}
+
+// send delivers the push events to the connected client.
+func (s *Sim) send(events ...byte) {
+	s.m.Lock()
+	notify := s.notify
+	s.m.Unlock()
+
+	if notify == nil {
+		return
+	}
+
+	for _, e := range events {
+		notify([]byte{e})
+	}
+}
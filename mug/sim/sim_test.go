@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package sim
+
+import (
+	"context"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug"
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/schmidtw/muggo/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStep(t *testing.T) {
+	tests := []struct {
+		description string
+		opts        []Option
+		steps       int
+		wantDrink   func(units.Temperature) bool
+		wantBattery func(float64) bool
+		wantState   byte
+		wantEvent   byte
+	}{
+		{
+			description: "heats toward the target on the coaster",
+			opts:        []Option{WithDrink(40), WithTarget(57), WithBattery(50)},
+			steps:       60,
+			wantDrink:   func(t units.Temperature) bool { return 42.9 < t && t < 43.1 },
+			wantBattery: func(b float64) bool { return 50 < b },
+			wantState:   stateHeating,
+			wantEvent:   mug.NOTIFY_DRINK_CHANGED,
+		}, {
+			description: "holds the target",
+			opts:        []Option{WithDrink(57), WithTarget(57)},
+			steps:       600,
+			wantDrink:   func(t units.Temperature) bool { return t == 57 },
+			wantState:   statePerfect,
+		}, {
+			description: "cools off the coaster",
+			opts:        []Option{WithDrink(57), WithTarget(57), WithBattery(80), OnCoaster(false)},
+			steps:       600,
+			wantDrink:   func(t units.Temperature) bool { return t < 50 },
+			wantBattery: func(b float64) bool { return b < 80 },
+			wantState:   stateCold,
+		}, {
+			description: "drains the battery off the coaster",
+			opts:        []Option{WithBattery(50), OnCoaster(false)},
+			steps:       60,
+			wantBattery: func(b float64) bool { return 48 < b && b < 49 },
+			wantEvent:   mug.NOTIFY_BATTERY,
+		}, {
+			description: "empty mugs cool",
+			opts:        []Option{WithDrink(57), WithLiquidLevel(0)},
+			steps:       60,
+			wantDrink:   func(t units.Temperature) bool { return t < 57 },
+			wantState:   stateEmpty,
+			wantEvent:   mug.NOTIFY_DRINK_CHANGED,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			s, err := New(tc.opts...)
+			require.NoError(err)
+
+			var events []byte
+			s.notify = func(buf []byte) {
+				events = append(events, buf...)
+			}
+
+			for i := 0; i < tc.steps; i++ {
+				s.Step(time.Second)
+			}
+
+			if tc.wantDrink != nil {
+				assert.True(tc.wantDrink(s.Drink()), "drink: %v", s.Drink())
+			}
+			if tc.wantBattery != nil {
+				assert.True(tc.wantBattery(s.Battery()), "battery: %v", s.Battery())
+			}
+			if tc.wantState != 0 {
+				assert.Equal(tc.wantState, s.state)
+			}
+			if tc.wantEvent != 0 {
+				assert.Contains(events, tc.wantEvent)
+			}
+		})
+	}
+}
+
+func TestMug(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := New(WithDrink(50), WithTarget(55))
+	require.NoError(err)
+
+	connected := make(chan bool, 1)
+	infos := make(chan mug.MugInfo, 100)
+	m, err := mug.New(
+		mug.WithTransport(s),
+		mug.SyncClockOnConnect(time.UTC),
+		mug.WithChangeConnectionListener(
+			event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+				connected <- cc.Connected
+			})),
+	)
+	require.NoError(err)
+	m.AddMugListener(mug.MugListenerFunc(func(mi mug.MugInfo) {
+		infos <- mi
+	}))
+
+	m.Start()
+	defer m.Stop()
+
+	select {
+	case got := <-connected:
+		require.True(got)
+	case <-time.After(time.Second):
+		require.FailNow("timed out waiting to connect")
+	}
+
+	all := m.All()
+	assert.Equal("Ember Sim", all.Name)
+	assert.Equal(units.Temperature(50), all.Drink)
+	assert.Equal(units.Temperature(55), all.Target)
+	assert.Equal(80.0, all.Battery.PercentLeft)
+	assert.True(all.Battery.Charging)
+	assert.Equal(mug.Heating, all.State)
+	assert.Equal(units.Celsius, all.Units)
+	assert.Equal("SIM0000001", all.DeviceInfo.SerialNumber)
+	assert.Equal(mug.ModelMug2, all.Model)
+	assert.True(m.Capabilities().LED)
+
+	// The clock is set when the mug connects.
+	clock, err := m.Clock()
+	require.NoError(err)
+	assert.WithinDuration(time.Now(), clock, time.Minute)
+
+	// Writes go through to the simulator.
+	_, err = m.Led(color.NRGBA{R: 1, G: 2, B: 3, A: 4})
+	require.NoError(err)
+	assert.Equal(color.NRGBA{R: 1, G: 2, B: 3, A: 4}, s.led)
+
+	// Push events are turned into listener updates.
+	s.Step(time.Minute)
+	wait(t, infos, func(mi mug.MugInfo) bool {
+		return mi.Drink == 53
+	})
+
+	s.PlaceOnCoaster(false)
+	wait(t, infos, func(mi mug.MugInfo) bool {
+		return !mi.Battery.Charging
+	})
+
+	s.Fill(0)
+	wait(t, infos, func(mi mug.MugInfo) bool {
+		return mi.Empty && mi.State == mug.Empty
+	})
+
+	s.Disconnect()
+	select {
+	case got := <-connected:
+		assert.False(got)
+	case <-time.After(time.Second):
+		require.FailNow("timed out waiting to disconnect")
+	}
+
+	// The mug reconnects once the simulator is back in range.
+	select {
+	case got := <-connected:
+		assert.True(got)
+	case <-time.After(time.Second):
+		require.FailNow("timed out waiting to reconnect")
+	}
+}
+
+func wait(t *testing.T, infos <-chan mug.MugInfo, match func(mug.MugInfo) bool) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for {
+		select {
+		case mi := <-infos:
+			if match(mi) {
+				return
+			}
+		case <-ctx.Done():
+			require.FailNow(t, "timed out waiting for mug info")
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := New(WithModel(Model(99)))
+	assert.ErrorIs(err, mug.ErrInvalidInput)
+
+	s, err := New()
+	require.NoError(err)
+
+	conn, err := s.Connect(context.Background())
+	require.NoError(err)
+	require.NotEmpty(conn.Characteristics)
+
+	s.Disconnect()
+	_, err = conn.Characteristics[0].Read(make([]byte, mtu))
+	assert.ErrorIs(err, mug.ErrNotConnected)
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package sim
+
+import (
+	"context"
+	"sync"
+
+	"github.com/schmidtw/muggo/mug"
+)
+
+// Transport connects to whichever of the simulated mugs is not connected,
+// the way a mug.Fleet connects to any mug in range.
+type Transport struct {
+	sims []*Sim
+}
+
+var _ mug.Transport = (*Transport)(nil)
+
+// NewTransport creates a Transport for the simulated mugs.
+func NewTransport(sims ...*Sim) *Transport {
+	return &Transport{
+		sims: sims,
+	}
+}
+
+// Connect connects to the first simulated mug that is not connected.  If
+// they are all connected, Connect blocks until one is disconnected or the
+// context is canceled.
+func (t *Transport) Connect(ctx context.Context) (*mug.Connection, error) {
+	for {
+		freed := make(chan struct{})
+		var once sync.Once
+
+		waitCtx, cancel := context.WithCancel(ctx)
+		for _, s := range t.sims {
+			s.m.Lock()
+			connected := s.connected
+			disconnected := s.disconnected
+			s.m.Unlock()
+
+			if !connected {
+				cancel()
+				return s.Connect(ctx)
+			}
+
+			go func() {
+				select {
+				case <-disconnected:
+					once.Do(func() { close(freed) })
+				case <-waitCtx.Done():
+				}
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			cancel()
+			return nil, ctx.Err()
+		case <-freed:
+			cancel()
+		}
+	}
+}
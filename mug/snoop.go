@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"io"
+
+	"github.com/schmidtw/muggo/mug/btsnoop"
+)
+
+// WithBTSnoop writes all the ATT reads, writes and notifications with the mug
+// to w in the btsnoop HCI log format, which opens directly in Wireshark.  The
+// ATT handles in the log are the characteristic ids (see CharacteristicID()).
+func WithBTSnoop(w io.Writer) Option {
+	snoop := btsnoop.NewWriter(w)
+	return WrapTransport(func(next Transport) Transport {
+		return TransportFunc(func(ctx context.Context) (*Connection, error) {
+			conn, err := next.Connect(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			rv := *conn
+			rv.Characteristics = make([]Characteristic, 0, len(conn.Characteristics))
+			for _, c := range conn.Characteristics {
+				rv.Characteristics = append(rv.Characteristics, &snoopedCharacteristic{
+					Characteristic: c,
+					snoop:          snoop,
+					handle:         uint16(CharacteristicID(c.UUID())),
+				})
+			}
+
+			_ = snoop.Connected(conn.Address.MAC.Address())
+			if conn.Disconnected != nil {
+				go func() {
+					<-conn.Disconnected
+					_ = snoop.Disconnected()
+				}()
+			}
+
+			return &rv, nil
+		})
+	})
+}
+
+type snoopedCharacteristic struct {
+	Characteristic
+	snoop  *btsnoop.Writer
+	handle uint16
+}
+
+func (c *snoopedCharacteristic) Read(data []byte) (int, error) {
+	n, err := c.Characteristic.Read(data)
+	_ = c.snoop.Read(c.handle, data[:max(n, 0)], err != nil)
+	return n, err
+}
+
+func (c *snoopedCharacteristic) WriteWithoutResponse(p []byte) (int, error) {
+	_ = c.snoop.WriteCommand(c.handle, p)
+	return c.Characteristic.WriteWithoutResponse(p)
+}
+
+func (c *snoopedCharacteristic) EnableNotifications(callback func(buf []byte)) error {
+	return c.Characteristic.EnableNotifications(func(buf []byte) {
+		_ = c.snoop.Notification(c.handle, buf)
+		callback(buf)
+	})
+}
@@ -4,6 +4,7 @@
 package mug
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -60,7 +61,12 @@ func (s State) IsUnknown() bool {
 
 // State returns the current state of the mug.
 func (m *Mug) State() (State, error) {
-	data, changed, err := m.io(m, mugApi_STATE, 1)
+	return m.StateContext(context.Background())
+}
+
+// StateContext is like State but uses the context for the request.
+func (m *Mug) StateContext(ctx context.Context) (State, error) {
+	data, changed, err := m.io(ctx, m, mugApi_STATE, 1)
 	if err != nil {
 		return Unknown, err
 	}
@@ -80,6 +86,10 @@ func (m *Mug) stateChanged() {
 }
 
 func stateFromData(data []byte) State {
+	if len(data) < 1 {
+		return Unknown
+	}
+
 	if state, ok := stateMap[data[0]]; ok {
 		return state
 	}
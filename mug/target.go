@@ -4,20 +4,45 @@
 package mug
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/schmidtw/muggo/units"
 )
 
+// The target temperature range the mugs accept.
+const (
+	MinTarget units.Temperature = 50
+	MaxTarget units.Temperature = 62.5
+)
+
+// CheckTarget returns ErrInvalidInput if the temperature is outside of the
+// range the mugs accept.
+func CheckTarget(temp units.Temperature) error {
+	// Written so NaN is rejected too.
+	if !(MinTarget <= temp && temp <= MaxTarget) {
+		return fmt.Errorf("%w: the target must be between %g°C and %g°C",
+			ErrInvalidInput, MinTarget.C(), MaxTarget.C())
+	}
+
+	return nil
+}
+
 // Target returns the target temperature of the mug.  If a temperature is
 // provided, the mug will be set to that temperature.
 func (m *Mug) Target(temp ...units.Temperature) (units.Temperature, error) {
+	return m.TargetContext(context.Background(), temp...)
+}
+
+// TargetContext is like Target but uses the context for the request.
+func (m *Mug) TargetContext(ctx context.Context, temp ...units.Temperature) (units.Temperature, error) {
 	var write [][]byte
 	if len(temp) > 0 {
 		write = [][]byte{temp[0].ToMug()}
 	}
 
-	data, changed, err := m.io(m, mugApi_TARGET, 2, write...)
+	data, changed, err := m.io(ctx, m, mugApi_TARGET, 2, write...)
 	if err != nil {
 		return 0, err
 	}
@@ -30,6 +55,10 @@ func (m *Mug) Target(temp ...units.Temperature) (units.Temperature, error) {
 }
 
 func targetFromData(data []byte) units.Temperature {
+	if len(data) < 2 {
+		return 0
+	}
+
 	return units.FromMug(data)
 }
 
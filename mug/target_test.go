@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/schmidtw/muggo/units"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTarget(t *testing.T) {
+	tests := []struct {
+		temp        units.Temperature
+		expectedErr error
+	}{
+		{temp: MinTarget},
+		{temp: 57},
+		{temp: MaxTarget},
+		{temp: 49.9, expectedErr: ErrInvalidInput},
+		{temp: 62.6, expectedErr: ErrInvalidInput},
+		{temp: units.Temperature(math.NaN()), expectedErr: ErrInvalidInput},
+		{temp: units.Temperature(math.Inf(1)), expectedErr: ErrInvalidInput},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%v", float64(tc.temp)), func(t *testing.T) {
+			assert.ErrorIs(t, CheckTarget(tc.temp), tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"fmt"
+
+	bt "tinygo.org/x/bluetooth"
+)
+
+// Characteristic is a single characteristic of a connected mug.  The
+// *bluetooth.DeviceCharacteristic type satisfies this interface, but any
+// backend (a simulator, a recording proxy, a remote bridge, ...) can be used.
+type Characteristic interface {
+	// UUID returns the UUID of the characteristic.
+	UUID() bt.UUID
+
+	// Read reads the current value of the characteristic into data and
+	// returns the number of bytes read.
+	Read(data []byte) (int, error)
+
+	// WriteWithoutResponse writes the value of the characteristic.
+	WriteWithoutResponse(p []byte) (int, error)
+
+	// GetMTU returns the largest value that can be read.
+	GetMTU() (uint16, error)
+
+	// EnableNotifications registers the callback that is called each time
+	// the characteristic sends a notification.
+	EnableNotifications(callback func(buf []byte)) error
+}
+
+// Connection is a connection to a single mug provided by a Transport.
+type Connection struct {
+	// Address is the address of the connected mug.
+	Address bt.Address
+
+	// ServiceUUIDs are the Ember services the mug provides, if known.  They
+	// are used to tell which model the mug is.
+	ServiceUUIDs []bt.UUID
+
+	// Characteristics are the characteristics of the mug services.
+	Characteristics []Characteristic
+
+	// Disconnected is closed when the connection to the mug is lost.
+	Disconnected <-chan struct{}
+
+	// Disconnect, if set, drops the connection to the mug.
+	Disconnect func() error
+}
+
+// Transport finds and connects to a mug.
+type Transport interface {
+	// Connect blocks until a mug has been connected to or the context is
+	// canceled.
+	Connect(ctx context.Context) (*Connection, error)
+}
+
+// TransportFunc is a convenience type for implementing the Transport
+// interface with a function.
+type TransportFunc func(context.Context) (*Connection, error)
+
+func (f TransportFunc) Connect(ctx context.Context) (*Connection, error) {
+	return f(ctx)
+}
+
+// WithTransport sets the transport used to connect to the mug.  If no
+// transport is provided, the bluetooth adapter is used.
+func WithTransport(t Transport) Option {
+	return OptionFunc(func(mug *Mug) error {
+		mug.transport = t
+		return nil
+	})
+}
+
+// WrapTransport wraps the transport used to connect to the mug.  Wrappers are
+// applied in the order provided after the transport is chosen, so they work
+// with the default bluetooth transport as well as WithTransport().
+func WrapTransport(wrapper func(Transport) Transport) Option {
+	return OptionFunc(func(mug *Mug) error {
+		if wrapper != nil {
+			mug.wrappers = append(mug.wrappers, wrapper)
+		}
+		return nil
+	})
+}
+
+func (m *Mug) wrap(t Transport) Transport {
+	for _, wrapper := range m.wrappers {
+		t = wrapper(t)
+	}
+	return t
+}
+
+// CharacteristicID returns the id of the characteristic with the specified
+// UUID.  The ids match the numbering the mug uses for its characteristics.
+func CharacteristicID(uuid bt.UUID) int {
+	return uuidToApiId(uuid)
+}
+
+// CharacteristicUUID returns the UUID of the characteristic with the
+// specified id.
+func CharacteristicUUID(id int) bt.UUID {
+	uuid, _ := bt.ParseUUID(fmt.Sprintf("fc54%04x-236c-4c94-8fa9-944a3e5353fa", 0xffff&id))
+	return uuid
+}
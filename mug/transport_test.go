@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/schmidtw/muggo/mug/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bt "tinygo.org/x/bluetooth"
+)
+
+type fakeCharacteristic struct {
+	uuid   bt.UUID
+	data   []byte
+	notify func([]byte)
+}
+
+func newFakeCharacteristic(api int, data ...byte) *fakeCharacteristic {
+	uuid, err := bt.ParseUUID(fmt.Sprintf("fc54%04x-236c-4c94-8fa9-944a3e5353fa", api))
+	if err != nil {
+		panic(err)
+	}
+	return &fakeCharacteristic{
+		uuid: uuid,
+		data: data,
+	}
+}
+
+func (f *fakeCharacteristic) UUID() bt.UUID {
+	return f.uuid
+}
+
+func (f *fakeCharacteristic) Read(data []byte) (int, error) {
+	return copy(data, f.data), nil
+}
+
+func (f *fakeCharacteristic) WriteWithoutResponse(p []byte) (int, error) {
+	f.data = append([]byte{}, p...)
+	return len(p), nil
+}
+
+func (f *fakeCharacteristic) GetMTU() (uint16, error) {
+	return 512, nil
+}
+
+func (f *fakeCharacteristic) EnableNotifications(callback func(buf []byte)) error {
+	f.notify = callback
+	return nil
+}
+
+func TestWithTransport(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	disconnected := make(chan struct{})
+	calls := 0
+	transport := TransportFunc(func(ctx context.Context) (*Connection, error) {
+		calls++
+		if calls > 1 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &Connection{
+			Characteristics: []Characteristic{
+				newFakeCharacteristic(mugApi_NAME, []byte("mug")...),
+				newFakeCharacteristic(mugApi_DRINK, 0x23, 0x16),
+				newFakeCharacteristic(mugApi_TARGET, 0x00, 0x15),
+				newFakeCharacteristic(mugApi_PUSH_EVENT),
+			},
+			Disconnected: disconnected,
+		}, nil
+	})
+
+	connected := make(chan bool, 2)
+	m, err := New(
+		WithTransport(transport),
+		WithChangeConnectionListener(
+			event.ConnectionChangeFunc(func(cc event.ConnectionChange) {
+				connected <- cc.Connected
+			})),
+	)
+	require.NoError(err)
+	require.NotNil(m)
+
+	m.Start()
+	defer m.Stop()
+
+	select {
+	case got := <-connected:
+		require.True(got)
+	case <-time.After(time.Second):
+		require.FailNow("timed out waiting to connect")
+	}
+
+	assert.True(m.IsConnected())
+
+	name, err := m.Name()
+	assert.NoError(err)
+	assert.Equal("mug", name)
+
+	drink, err := m.Drink()
+	assert.NoError(err)
+	assert.Equal("56.67", fmt.Sprintf("%.2f", drink.C()))
+
+	// The mug doesn't have a LED.
+	_, err = m.Led()
+	assert.ErrorIs(err, ErrNotSupported)
+
+	close(disconnected)
+
+	select {
+	case got := <-connected:
+		assert.False(got)
+	case <-time.After(time.Second):
+		require.FailNow("timed out waiting to disconnect")
+	}
+	assert.False(m.IsConnected())
+}
+
+func TestCharacteristicID(t *testing.T) {
+	assert := assert.New(t)
+
+	uuid, err := bt.ParseUUID("fc540014-236c-4c94-8fa9-944a3e5353fa")
+	assert.NoError(err)
+	assert.Equal(mugApi_LED, CharacteristicID(uuid))
+
+	for id := 0; id < mugApi_LAST; id++ {
+		assert.Equal(id, CharacteristicID(CharacteristicUUID(id)))
+	}
+}
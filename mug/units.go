@@ -4,6 +4,7 @@
 package mug
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -14,6 +15,11 @@ import (
 // will be set to that unit.
 // This function only appears to be useful if the mug has a display.
 func (m *Mug) Units(unit ...units.TemperatureUnit) (units.TemperatureUnit, error) {
+	return m.UnitsContext(context.Background(), unit...)
+}
+
+// UnitsContext is like Units but uses the context for the request.
+func (m *Mug) UnitsContext(ctx context.Context, unit ...units.TemperatureUnit) (units.TemperatureUnit, error) {
 	var write [][]byte
 	if len(unit) > 0 {
 		t := []byte{0}
@@ -28,7 +34,7 @@ func (m *Mug) Units(unit ...units.TemperatureUnit) (units.TemperatureUnit, error
 		write = [][]byte{t}
 	}
 
-	data, _, err := m.io(m, mugApi_UNITS, 2, write...)
+	data, _, err := m.io(ctx, m, mugApi_UNITS, 2, write...)
 	if err != nil {
 		return units.Unknown, err
 	}
@@ -42,6 +48,10 @@ func (m *Mug) Units(unit ...units.TemperatureUnit) (units.TemperatureUnit, error
 }
 
 func unitsFromData(data []byte) units.TemperatureUnit {
+	if len(data) < 1 {
+		return units.Unknown
+	}
+
 	switch data[0] {
 	case 0:
 		return units.Celsius
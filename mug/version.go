@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a firmware or bootloader version.  The mug packs the version
+// into 16 bits with the major version in the high byte and the minor and
+// patch versions in the high and low nibbles of the low byte, so 0x0252 is
+// version 2.5.2.  Ember does not document the packing, it is inferred from
+// the versions the Ember app shows and may not hold for every mug.
+type Version uint16
+
+// Major returns the major version.
+func (v Version) Major() int {
+	return int(v >> 8)
+}
+
+// Minor returns the minor version.
+func (v Version) Minor() int {
+	return int(v>>4) & 0x0f
+}
+
+// Patch returns the patch version.
+func (v Version) Patch() int {
+	return int(v) & 0x0f
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+}
+
+// Compare returns -1 if v is older than other, 0 if they are the same and +1
+// if v is newer than other.
+func (v Version) Compare(other Version) int {
+	return cmp.Compare(v, other)
+}
+
+// ParseVersion parses a version in the major.minor.patch form.  The minor
+// and patch versions may be left off.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "v"), ".")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidInput, s)
+	}
+
+	limits := []uint64{0xff, 0x0f, 0x0f}
+	nums := make([]uint64, 3)
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 8)
+		if err != nil || n > limits[i] {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidInput, s)
+		}
+		nums[i] = n
+	}
+
+	return Version(nums[0]<<8 | nums[1]<<4 | nums[2]), nil
+}
+
+// MugID is the identifier from the first 6 bytes of the ID characteristic,
+// which come before the serial number.
+type MugID [6]byte
+
+func (id MugID) String() string {
+	return fmt.Sprintf("%X", id[:])
+}
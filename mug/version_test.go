@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		want        Version
+		str         string
+		err         bool
+	}{
+		{
+			description: "full version",
+			in:          "2.5.2",
+			want:        0x0252,
+			str:         "2.5.2",
+		}, {
+			description: "leading v and major only",
+			in:          "v1",
+			want:        0x0100,
+			str:         "1.0.0",
+		}, {
+			description: "minor too large",
+			in:          "1.16",
+			err:         true,
+		}, {
+			description: "too many parts",
+			in:          "1.2.3.4",
+			err:         true,
+		}, {
+			description: "not a number",
+			in:          "one",
+			err:         true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, err := ParseVersion(tc.in)
+			if tc.err {
+				assert.ErrorIs(err, ErrInvalidInput)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.want, got)
+			assert.Equal(tc.str, got.String())
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(-1, Version(0x0252).Compare(0x0300))
+	assert.Equal(0, Version(0x0252).Compare(0x0252))
+	assert.Equal(1, Version(0x0260).Compare(0x0252))
+}
+
+func Test_deviceInfoFromData(t *testing.T) {
+	assert := assert.New(t)
+
+	serial := append([]byte{0xc0, 0xff, 0xee, 0x00, 0x01, 0x02}, []byte("SIM-0000001")...)
+
+	di := deviceInfoFromData([]byte{0x52, 0x02, 0x02, 0x00, 0x06, 0x00}, serial)
+	assert.Equal(&DeviceInfo{
+		FirmwareVersion:   0x0252,
+		HardwareVersion:   2,
+		BootloaderVersion: 0x0006,
+		SerialNumber:      "SIM0000001",
+		MugID:             MugID{0xc0, 0xff, 0xee, 0x00, 0x01, 0x02},
+	}, di)
+	assert.Equal("C0FFEE000102", di.MugID.String())
+	assert.Equal("2.5.2", di.Firmware().String())
+	assert.Equal("0.0.6", di.Bootloader().String())
+	assert.Equal("hardware 2, firmware 2.5.2, bootloader 0.0.6, serial SIM0000001, id C0FFEE000102", di.String())
+
+	assert.Nil(deviceInfoFromData([]byte{0x01}, serial))
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"time"
+
+	"github.com/schmidtw/muggo/units"
+)
+
+// Volume returns the volume of the drink reported by the mug.  Only the
+// cups and tumblers report volume, so other mugs return ErrNotConnected or
+// ErrNotSupported.
+func (m *Mug) Volume() (units.Volume, error) {
+	return m.VolumeContext(context.Background())
+}
+
+// VolumeContext is like Volume but uses the context for the request.
+func (m *Mug) VolumeContext(ctx context.Context) (units.Volume, error) {
+	data, changed, err := m.io(ctx, m, mugApi_VOLUME, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	if changed {
+		m.dispatch()
+	}
+
+	return volumeFromData(data), nil
+}
+
+func volumeFromData(data []byte) units.Volume {
+	if len(data) < 2 {
+		return 0
+	}
+
+	return units.VolumeFromMug(data)
+}
+
+// VolumeTTL sets the TTL for the volume of the drink in the mug.
+func VolumeTTL(ttl time.Duration) Option {
+	return OptionFunc(func(mug *Mug) error {
+		mug.apis[mugApi_VOLUME].ttl = ttl
+		return nil
+	})
+}
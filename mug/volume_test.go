@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"testing"
+
+	"github.com/schmidtw/muggo/units"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMug_Volume(t *testing.T) {
+	tests := []struct {
+		description string
+		data        []byte
+		missing     bool
+		want        units.Volume
+		expectedErr error
+	}{
+		{
+			description: "the volume in milliliters",
+			data:        []byte{0x27, 0x01},
+			want:        units.Volume(295),
+		}, {
+			description: "empty",
+			data:        []byte{0x00, 0x00},
+		}, {
+			description: "the wrong length",
+			data:        []byte{0x27},
+			expectedErr: ErrNotSupported,
+		}, {
+			description: "not connected",
+			missing:     true,
+			expectedErr: ErrNotConnected,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newIdleMug(t)
+			if !tc.missing {
+				m.apis[mugApi_VOLUME].characteristic = newFakeCharacteristic(mugApi_VOLUME, tc.data...)
+			}
+
+			got, err := m.Volume()
+			assert.ErrorIs(err, tc.expectedErr)
+			assert.Equal(tc.want, got)
+			if err == nil {
+				assert.Equal(tc.want, m.All().Volume)
+			}
+		})
+	}
+}
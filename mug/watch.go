@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// Watch returns an iterator over the mug information, yielding the current
+// information first and then each time it changes.  If the loop falls behind,
+// the intermediate values are skipped and only the latest is yielded.  The
+// iteration ends when the context is done or the loop exits.
+func (m *Mug) Watch(ctx context.Context) iter.Seq[MugInfo] {
+	return func(yield func(MugInfo) bool) {
+		for c := range m.WatchFields(ctx) {
+			if !yield(c.Current) {
+				return
+			}
+		}
+	}
+}
+
+// WatchFields returns an iterator over the changes to the fields.  No fields
+// means all fields.  The first change yielded is from the zero MugInfo to the
+// current information, so the loop starts with the current values.  If the
+// loop falls behind, the pending changes are combined so the change yielded
+// goes from the last value yielded to the latest value.  The iteration ends
+// when the context is done or the loop exits.
+func (m *Mug) WatchFields(ctx context.Context, fields ...Field) iter.Seq[MugChange] {
+	return func(yield func(MugChange) bool) {
+		var (
+			lock    sync.Mutex
+			pending *MugChange
+		)
+
+		ready := make(chan struct{}, 1)
+		cancel := m.AddMugChangeListener(MugChangeFunc(func(c MugChange) {
+			lock.Lock()
+			if pending != nil {
+				c = diffMugInfo(pending.Previous, c.Current).filter(fields)
+			}
+			pending = &c
+			if len(c.Changes) == 0 {
+				// The fields changed back, so there is nothing to report.
+				pending = nil
+			}
+			lock.Unlock()
+
+			select {
+			case ready <- struct{}{}:
+			default:
+			}
+		}), fields...)
+		defer cancel()
+
+		// The listener is added first so nothing after the current
+		// information is missed.
+		last := m.All()
+		if !yield(diffMugInfo(MugInfo{}, last).filter(fields)) {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ready:
+			}
+
+			lock.Lock()
+			c := pending
+			pending = nil
+			lock.Unlock()
+
+			if c == nil {
+				continue
+			}
+
+			// Changes made before the current information was read are
+			// already included in it.
+			change := diffMugInfo(last, c.Current).filter(fields)
+			if len(change.Changes) == 0 {
+				continue
+			}
+			last = change.Current
+
+			if !yield(change) {
+				return
+			}
+		}
+	}
+}
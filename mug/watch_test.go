@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package mug
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"github.com/schmidtw/muggo/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (m *Mug) hasMugChangeListeners() bool {
+	var found bool
+	m.mugChangeListeners.Visit(func(*queue[MugChange]) {
+		found = true
+	})
+	return found
+}
+
+func (m *Mug) setData(api int, data []byte) {
+	m.m.Lock()
+	m.apis[api].data = data
+	m.m.Unlock()
+	m.dispatch()
+}
+
+func newIdleMug(t *testing.T) *Mug {
+	m, err := New(WithTransport(TransportFunc(func(ctx context.Context) (*Connection, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})))
+	require.NoError(t, err)
+	return m
+}
+
+func TestMug_Watch(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newIdleMug(t)
+	m.setData(mugApi_DRINK, units.Temperature(54).ToMug())
+
+	got := make(chan MugInfo)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for info := range m.Watch(context.Background()) {
+			got <- info
+			if info.Drink == 56 {
+				return
+			}
+		}
+	}()
+
+	// The current information comes first.
+	assert.Equal(units.Temperature(54), (<-got).Drink)
+
+	m.setData(mugApi_DRINK, units.Temperature(55).ToMug())
+	assert.Equal(units.Temperature(55), (<-got).Drink)
+	m.setData(mugApi_DRINK, units.Temperature(56).ToMug())
+	assert.Equal(units.Temperature(56), (<-got).Drink)
+
+	// Exiting the loop removes the listener.
+	<-done
+	assert.False(m.hasMugChangeListeners())
+}
+
+func TestMug_WatchFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	m := newIdleMug(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	next, stop := iter.Pull(m.WatchFields(ctx, FieldDrink, FieldTarget))
+	defer stop()
+
+	m.setData(mugApi_TARGET, units.Temperature(57).ToMug())
+
+	// The current values come first, then the changes while nobody is
+	// reading.
+	c, ok := next()
+	require.True(ok)
+	assert.Equal([]Field{FieldTarget}, fields(c))
+	assert.Equal(units.Temperature(57), c.Current.Target)
+
+	// These are coalesced while nobody is reading, and the name is ignored.
+	m.setData(mugApi_DRINK, units.Temperature(50).ToMug())
+	m.setData(mugApi_NAME, []byte("mug"))
+	m.setData(mugApi_DRINK, units.Temperature(52).ToMug())
+
+	prev := units.Temperature(0)
+	for prev != 52 {
+		c, ok := next()
+		require.True(ok)
+		require.Equal([]Field{FieldDrink}, fields(c))
+		was, now, ok := FieldValues[units.Temperature](c.Changes[0])
+		require.True(ok)
+		assert.Equal(prev, was)
+		prev = now
+	}
+
+	// Canceling the context ends the iteration.
+	cancel()
+	_, ok = next()
+	assert.False(ok)
+	assert.False(m.hasMugChangeListeners())
+}
@@ -56,6 +56,18 @@ func (p *Personal) Start() {
 				p.nameWidget.SetText(p.name)
 				p.nameWidget.Refresh()
 			}
+
+			// Only show the LED picker on mugs that have a LED.
+			if !p.m.Capabilities().LED {
+				if p.c != nil {
+					p.c.Hide()
+				}
+				continue
+			}
+			if p.c != nil {
+				p.c.Show()
+			}
+
 			p.led, err = p.m.Led()
 			if err == nil {
 				p.ledWidget.SetColor(p.led)
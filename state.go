@@ -4,8 +4,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"image/color"
+	"log/slog"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -33,6 +36,7 @@ const (
 )
 
 type State struct {
+	lock      sync.Mutex
 	m         *mug.Mug
 	states    map[int]*canvas.Image
 	goalEntry *widget.Entry
@@ -122,7 +126,7 @@ func NewState(m *mug.Mug, w fyne.Window) *State {
 					if err == nil {
 						_, err = s.m.Target(temp)
 						if err != nil {
-							fmt.Println(err)
+							slog.Warn("unable to set the target temperature", slog.Any("err", err))
 						}
 						if s.rg.Selected == "C" {
 							s.goal.Text = fmt.Sprintf("%0.01f °C", temp.C())
@@ -161,85 +165,95 @@ func NewState(m *mug.Mug, w fyne.Window) *State {
 }
 
 func (s *State) Start() {
-	go func() {
-		mugChanges := make(chan mug.MugInfo, 1)
-		s.m.AddMugListener(mug.MugListenerFunc(func(info mug.MugInfo) {
-			mugChanges <- info
-		}))
+	// Show why the mug is not connected, and redraw everything once it is.
+	s.m.AddStateChangeListener(event.StateChangeFunc(func(sc event.StateChange) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
 
-		conChanges := make(chan event.ConnectionChange, 1)
-		s.m.AddConnectionChangeListener(event.ConnectionChangeFunc(func(info event.ConnectionChange) {
-			conChanges <- info
-		}))
+		if sc.State != event.Connected {
+			s.icon = s.states[MUG_NONE]
+			s.temp.Text = sc.State.String()
+			s.refresh()
+			return
+		}
 
-		for {
-			var info mug.MugInfo
+		info := s.m.All()
+		if info.Model.Capabilities().DisplayUnits {
+			s.rg.Show()
+		} else {
+			s.rg.Hide()
+		}
+		s.show(info)
+	}))
 
-			s.icon.Refresh()
-			s.goal.Refresh()
-			s.temp.Refresh()
-			s.rg.Refresh()
-			if s.c != nil {
-				s.c.Objects[0] = s.temp
-				s.c.Objects[1] = s.icon
-				s.c.Refresh()
+	go func() {
+		for info := range s.m.Watch(context.Background()) {
+			s.lock.Lock()
+			if s.m.ConnectionState() == event.Connected {
+				s.show(info)
 			}
+			s.lock.Unlock()
+		}
+	}()
+}
 
-			select {
-			case info = <-mugChanges:
-			case con := <-conChanges:
-				if !con.Connected {
-					s.icon = s.states[MUG_NONE]
-					continue
-				}
+// show displays the mug information.  The lock must be held.
+func (s *State) show(info mug.MugInfo) {
+	defer s.refresh()
 
-				info = s.m.All()
-			}
+	if info.State.IsUnknown() {
+		slog.Info("unknown mug state", slog.String("state", info.State.String()))
+	}
 
-			//fmt.Printf("target: %v\n", target)
-			//fmt.Printf("current: %v\n", current)
-			if info.State.IsUnknown() {
-				fmt.Printf("state: %s\n", info.State.String())
-			}
+	zone := calcTempZone(info.Drink, info.Target)
+	switch {
+	case info.State == mug.Empty:
+		s.icon = s.states[MUG_EMPTY]
+	case zone == MUG_COLD:
+		s.icon = s.states[MUG_COLD]
+		if info.State == mug.Heating {
+			s.icon = s.states[MUG_COLD_HEATING]
+		}
+	case zone == MUG_COOL:
+		s.icon = s.states[MUG_COOL]
+		if info.State == mug.Heating {
+			s.icon = s.states[MUG_COOL_HEATING]
+		}
+	case zone == MUG_PERFECT:
+		s.icon = s.states[MUG_PERFECT]
+		if info.State == mug.Heating {
+			s.icon = s.states[MUG_PERFECT_HEATING]
+		}
+	case zone == MUG_WARM:
+		s.icon = s.states[MUG_WARM]
+	case zone == MUG_HOT:
+		s.icon = s.states[MUG_HOT]
+	}
 
-			zone := calcTempZone(info.Drink, info.Target)
-			switch {
-			case info.State == mug.Empty:
-				s.icon = s.states[MUG_EMPTY]
-			case zone == MUG_COLD:
-				s.icon = s.states[MUG_COLD]
-				if info.State == mug.Heating {
-					s.icon = s.states[MUG_COLD_HEATING]
-				}
-			case zone == MUG_COOL:
-				s.icon = s.states[MUG_COOL]
-				if info.State == mug.Heating {
-					s.icon = s.states[MUG_COOL_HEATING]
-				}
-			case zone == MUG_PERFECT:
-				s.icon = s.states[MUG_PERFECT]
-				if info.State == mug.Heating {
-					s.icon = s.states[MUG_PERFECT_HEATING]
-				}
-			case zone == MUG_WARM:
-				s.icon = s.states[MUG_WARM]
-			case zone == MUG_HOT:
-				s.icon = s.states[MUG_HOT]
-			}
+	if info.Units == units.Celsius {
+		s.rg.Selected = "C"
+		s.goal.Text = fmt.Sprintf("%0.01f °C", info.Target.C())
+		s.goalEntry.Text = fmt.Sprintf("%0.01f °C", info.Target.C())
+		s.temp.Text = fmt.Sprintf("%0.01f °C", info.Drink.C())
+	} else {
+		s.rg.Selected = "F"
+		s.goal.Text = fmt.Sprintf("%0.01f °F", info.Target.F())
+		s.goalEntry.Text = fmt.Sprintf("%0.01f °F", info.Target.F())
+		s.temp.Text = fmt.Sprintf("%0.01f °F", info.Drink.F())
+	}
+}
 
-			if info.Units == units.Celsius {
-				s.rg.Selected = "C"
-				s.goal.Text = fmt.Sprintf("%0.01f °C", info.Target.C())
-				s.goalEntry.Text = fmt.Sprintf("%0.01f °C", info.Target.C())
-				s.temp.Text = fmt.Sprintf("%0.01f °C", info.Drink.C())
-			} else {
-				s.rg.Selected = "F"
-				s.goal.Text = fmt.Sprintf("%0.01f °F", info.Target.F())
-				s.goalEntry.Text = fmt.Sprintf("%0.01f °F", info.Target.F())
-				s.temp.Text = fmt.Sprintf("%0.01f °F", info.Drink.F())
-			}
-		}
-	}()
+// refresh redraws the widgets.  The lock must be held.
+func (s *State) refresh() {
+	s.icon.Refresh()
+	s.goal.Refresh()
+	s.temp.Refresh()
+	s.rg.Refresh()
+	if s.c != nil {
+		s.c.Objects[0] = s.temp
+		s.c.Objects[1] = s.icon
+		s.c.Refresh()
+	}
 }
 
 func (s *State) Layout() *fyne.Container {
@@ -11,7 +11,8 @@ import (
 )
 
 var (
-	// ErrInvalidInput is returned when the input is not a valid temperature.
+	// ErrInvalidInput is returned when the input is not a valid temperature or
+	// volume.
 	ErrInvalidInput = errors.New("invalid input")
 )
 
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package units
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// VolumeUnit is the unit a volume is given or shown in.
+type VolumeUnit string
+
+const (
+	Milliliters VolumeUnit = "ml"
+	FluidOunces VolumeUnit = "fl oz"
+)
+
+// The number of milliliters in a US fluid ounce.
+const mlPerFlOz = 29.5735295625
+
+// Volume is a volume in milliliters.
+type Volume float64
+
+// ML returns the volume in milliliters.
+func (v Volume) ML() float64 {
+	return float64(v)
+}
+
+// FlOz returns the volume in US fluid ounces.
+func (v Volume) FlOz() float64 {
+	return float64(v) / mlPerFlOz
+}
+
+// In returns the volume in the unit.  Unknown units are treated as
+// milliliters.
+func (v Volume) In(unit VolumeUnit) float64 {
+	if unit == FluidOunces {
+		return v.FlOz()
+	}
+	return v.ML()
+}
+
+// StringIn formats the volume in the unit, such as "414 ml" or "14.0 fl oz".
+// Unknown units are treated as milliliters.
+func (v Volume) StringIn(unit VolumeUnit) string {
+	if unit == FluidOunces {
+		return fmt.Sprintf("%.1f %s", v.FlOz(), FluidOunces)
+	}
+	return fmt.Sprintf("%.0f %s", v.ML(), Milliliters)
+}
+
+// ParseVolume parses a string into a Volume and returns the unit it was
+// given in.  The string can be in either milliliters (ml) or US fluid ounces
+// (fl oz, floz or oz).  If no unit is provided milliliters are assumed.
+func ParseVolume(s string) (Volume, VolumeUnit, error) {
+	s = strings.Replace(s, " ", "", -1)
+	s = strings.Replace(s, "\t", "", -1)
+	s = strings.TrimSpace(s)
+	s = strings.ToLower(s)
+
+	unit := Milliliters
+	switch {
+	case strings.HasSuffix(s, "floz"):
+		s = strings.TrimSuffix(s, "floz")
+		unit = FluidOunces
+	case strings.HasSuffix(s, "oz"):
+		s = strings.TrimSuffix(s, "oz")
+		unit = FluidOunces
+	case strings.HasSuffix(s, "ml"):
+		s = strings.TrimSuffix(s, "ml")
+	}
+
+	num, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, "", errors.Join(ErrInvalidInput, err)
+	}
+
+	if num < 0 || math.IsNaN(num) || math.IsInf(num, 0) {
+		return 0, "", ErrInvalidInput
+	}
+
+	if unit == Milliliters {
+		return Volume(num), unit, nil
+	}
+
+	return Volume(num * mlPerFlOz), unit, nil
+}
+
+// VolumeFromMug converts the byte representation of the volume in
+// milliliters little endian to a Volume.
+func VolumeFromMug(data []byte) Volume {
+	return Volume(binary.LittleEndian.Uint16(data))
+}
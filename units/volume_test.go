@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2023 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package units
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVolume(t *testing.T) {
+	tests := []struct {
+		in          string
+		want        string
+		unit        VolumeUnit
+		expectedErr error
+	}{
+		{
+			in:   "0",
+			want: "0.0000",
+			unit: Milliliters,
+		}, {
+			in:   "295",
+			want: "295.0000",
+			unit: Milliliters,
+		}, {
+			in:   "295ml",
+			want: "295.0000",
+			unit: Milliliters,
+		}, {
+			in:   " 295 mL ",
+			want: "295.0000",
+			unit: Milliliters,
+		}, {
+			in:   "10 fl oz",
+			want: "295.7353",
+			unit: FluidOunces,
+		}, {
+			in:   "10floz",
+			want: "295.7353",
+			unit: FluidOunces,
+		}, {
+			in:   "10 OZ",
+			want: "295.7353",
+			unit: FluidOunces,
+		}, {
+			in:          "-1ml",
+			expectedErr: ErrInvalidInput,
+		}, {
+			in:          "NaN",
+			expectedErr: ErrInvalidInput,
+		}, {
+			in:          "inf ml",
+			expectedErr: ErrInvalidInput,
+		}, {
+			in:          "+Inf oz",
+			expectedErr: ErrInvalidInput,
+		}, {
+			in:          "10 cups",
+			expectedErr: ErrInvalidInput,
+		}, {
+			in:          "1.2.3",
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, unit, err := ParseVolume(tc.in)
+
+			if tc.expectedErr == nil {
+				assert.Equal(tc.want, fmt.Sprintf("%.4f", got.ML()))
+				assert.Equal(tc.unit, unit)
+				assert.NoError(err)
+				return
+			}
+			assert.ErrorIs(err, tc.expectedErr)
+			assert.Zero(got)
+			assert.Empty(unit)
+		})
+	}
+}
+
+func TestVolume_FlOz(t *testing.T) {
+	tests := []struct {
+		v    Volume
+		want string
+	}{
+		{
+			v:    Volume(0),
+			want: "0.0000",
+		}, {
+			v:    Volume(414),
+			want: "13.9990",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tc.want, fmt.Sprintf("%.4f", tc.v.FlOz()))
+		})
+	}
+}
+
+func TestVolume_StringIn(t *testing.T) {
+	tests := []struct {
+		v     Volume
+		unit  VolumeUnit
+		want  string
+		value string
+	}{
+		{
+			v:     Volume(414),
+			unit:  Milliliters,
+			want:  "414 ml",
+			value: "414.0000",
+		}, {
+			v:     Volume(414),
+			unit:  FluidOunces,
+			want:  "14.0 fl oz",
+			value: "13.9990",
+		}, {
+			v:     Volume(295.7),
+			unit:  Milliliters,
+			want:  "296 ml",
+			value: "295.7000",
+		}, {
+			v:     Volume(0),
+			unit:  FluidOunces,
+			want:  "0.0 fl oz",
+			value: "0.0000",
+		}, {
+			v:     Volume(414),
+			unit:  "cups",
+			want:  "414 ml",
+			value: "414.0000",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tc.want, tc.v.StringIn(tc.unit))
+			assert.Equal(tc.value, fmt.Sprintf("%.4f", tc.v.In(tc.unit)))
+		})
+	}
+}
+
+func TestVolumeFromMug(t *testing.T) {
+	tests := []struct {
+		out  string
+		data []byte
+	}{
+		{
+			out:  "0.0000",
+			data: []byte{0x00, 0x00},
+		}, {
+			out:  "295.0000",
+			data: []byte{0x27, 0x01},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.out, func(t *testing.T) {
+			assert := assert.New(t)
+
+			v := VolumeFromMug(tc.data)
+			assert.Equal(tc.out, fmt.Sprintf("%.4f", v.ML()))
+		})
+	}
+}